@@ -0,0 +1,71 @@
+package aplos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type listAccountGroupsResponse struct {
+	Version string
+	Status  int
+	listPage
+	Data listAccountGroupsResponseData
+}
+
+type listAccountGroupsResponseData struct {
+	AccountGroups []AccountGroup `json:"account_groups"`
+}
+
+// AccountGroups returns the groups used to organize the chart of accounts, in
+// the order the API returns them. All pages of results are loaded.
+func (c *Client) AccountGroups(ctx context.Context) ([]AccountGroup, error) {
+	var groups []AccountGroup
+	err := forEachPage(ctx, c, c.url("/accountgroups", url.Values{}), func(r *listAccountGroupsResponse) bool {
+		if groups == nil {
+			groups = make([]AccountGroup, 0, r.Meta.RecordCount)
+		}
+		groups = append(groups, r.Data.AccountGroups...)
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list account groups: %w", err)
+	}
+	return groups, nil
+}
+
+type getAccountGroupResponse struct {
+	Version string
+	Status  int
+	Data    getAccountGroupResponseData
+}
+
+type getAccountGroupResponseData struct {
+	AccountGroup AccountGroup `json:"account_group"`
+}
+
+// accountGroupRequest is the body of a request to create an account group.
+type accountGroupRequest struct {
+	Name string `json:"name"`
+	Seq  int    `json:"seq,omitempty"`
+}
+
+// CreateAccountGroup adds an account group, returning the created group with
+// its server-assigned ID, which can then be set as the AccountGroup of accounts
+// passed to CreateAccount. The group's name must be set, and its Seq controls
+// where it's listed relative to other groups. The ID is ignored.
+func (c *Client) CreateAccountGroup(ctx context.Context, group AccountGroup) (*AccountGroup, error) {
+	if group.Name == "" {
+		return nil, errors.New("account group name must be set")
+	}
+	req := &accountGroupRequest{Name: group.Name, Seq: group.Seq}
+
+	var gResp getAccountGroupResponse
+	if err := c.send(ctx, http.MethodPost, "/accountgroups", req, &gResp); err != nil {
+		return nil, fmt.Errorf("failed to create account group: %w", err)
+	}
+
+	return &gResp.Data.AccountGroup, nil
+}
@@ -0,0 +1,56 @@
+package aplos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestAccountGroups(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/accountgroups" {
+			t.Errorf("request path = %q, want /accountgroups", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"status": 200, "data": {"account_groups": [{"id": 1, "name": "Cash", "seq": 10}, {"id": 2, "name": "Program Expenses", "seq": 20}]}}`)
+	}))
+
+	got, err := c.AccountGroups(context.Background())
+	if err != nil {
+		t.Fatalf("AccountGroups: %v", err)
+	}
+	want := []AccountGroup{{ID: 1, Name: "Cash", Seq: 10}, {ID: 2, Name: "Program Expenses", Seq: 20}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AccountGroups() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCreateAccountGroup(t *testing.T) {
+	var gotBody map[string]interface{}
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/accountgroups" {
+			t.Errorf("request = %s %s, want POST /accountgroups", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		fmt.Fprint(w, `{"status": 200, "data": {"account_group": {"id": 7, "name": "Fundraising", "seq": 30}}}`)
+	}))
+
+	got, err := c.CreateAccountGroup(context.Background(), AccountGroup{ID: 99, Name: "Fundraising", Seq: 30})
+	if err != nil {
+		t.Fatalf("CreateAccountGroup: %v", err)
+	}
+	if want := (&AccountGroup{ID: 7, Name: "Fundraising", Seq: 30}); !reflect.DeepEqual(got, want) {
+		t.Errorf("CreateAccountGroup() = %+v, want %+v", got, want)
+	}
+	if want := map[string]interface{}{"name": "Fundraising", "seq": 30.0}; !reflect.DeepEqual(gotBody, want) {
+		t.Errorf("request body = %v, want %v", gotBody, want)
+	}
+
+	if _, err := c.CreateAccountGroup(context.Background(), AccountGroup{}); err == nil {
+		t.Error("CreateAccountGroup without a name returned no error, want one")
+	}
+}
@@ -0,0 +1,138 @@
+package aplos
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// Amount is an amount of money, stored as a whole number of cents so that
+// sums are exact, unlike with float64, where adding up many amounts picks up
+// rounding error. Amounts can be added, subtracted, and compared as integers.
+// Since constants are in cents, it helps to write them with a separator before
+// the cents, like 1234_56 for $1,234.56, or to use NewAmount.
+//
+// In JSON, an Amount is a decimal number of dollars, like 1234.56, which is
+// how the Aplos API represents amounts.
+type Amount int64
+
+// NewAmount returns the amount nearest to the given number of dollars,
+// rounding half-cents away from zero.
+func NewAmount(dollars float64) Amount {
+	return Amount(math.Round(dollars * 100))
+}
+
+// ParseAmount parses a decimal number of dollars, like "-1234.56", exactly.
+// Amounts with fractions of a cent, other than trailing zeros, are rejected.
+func ParseAmount(s string) (Amount, error) {
+	if a, ok := parseAmountFast(s); ok {
+		return a, nil
+	}
+	// Fall back to arbitrary precision for anything unusual, like exponents.
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	r.Mul(r, big.NewRat(100, 1))
+	if !r.IsInt() {
+		return 0, fmt.Errorf("amount %q has a fraction of a cent", s)
+	}
+	if !r.Num().IsInt64() {
+		return 0, fmt.Errorf("amount %q is out of range", s)
+	}
+	return Amount(r.Num().Int64()), nil
+}
+
+// parseAmountFast parses amounts of the form "-123.45" without allocating. It
+// returns false for anything else, including amounts that are too large.
+func parseAmountFast(s string) (Amount, bool) {
+	neg := strings.HasPrefix(s, "-")
+	if neg {
+		s = s[1:]
+	}
+	whole, frac, _ := strings.Cut(s, ".")
+	// Trailing zeros past the cents are fine, e.g. "1.500".
+	if len(frac) > 2 {
+		if strings.TrimRight(frac[2:], "0") != "" {
+			return 0, false
+		}
+		frac = frac[:2]
+	}
+	if whole == "" || len(whole) > 15 {
+		return 0, false
+	}
+	var cents int64
+	for _, part := range [...]string{whole, frac, "00"[len(frac):]} {
+		for i := 0; i < len(part); i++ {
+			c := part[i]
+			if c < '0' || c > '9' {
+				return 0, false
+			}
+			cents = cents*10 + int64(c-'0')
+		}
+	}
+	if neg {
+		cents = -cents
+	}
+	return Amount(cents), true
+}
+
+// Float64 returns the amount in dollars, e.g. for formatting or for
+// calculations that aren't exact anyway, like ratios.
+func (a Amount) Float64() float64 {
+	return float64(a) / 100
+}
+
+// Cents returns the amount as a whole number of cents.
+func (a Amount) Cents() int64 {
+	return int64(a)
+}
+
+// Abs returns the absolute value of the amount.
+func (a Amount) Abs() Amount {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// String formats the amount as a decimal number of dollars with two decimal
+// places, like "-1234.50".
+func (a Amount) String() string {
+	sign, u := "", uint64(a)
+	if a < 0 {
+		sign, u = "-", -u
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, u/100, u%100)
+}
+
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON accepts amounts as JSON numbers or strings, parsing them
+// exactly, as described in ParseAmount.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	s := string(data)
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return fmt.Errorf("failed to unmarshal amount as a string: %w", err)
+		}
+		s = strings.TrimSpace(s)
+		if s == "" {
+			return errors.New("amount is an empty string")
+		}
+	}
+	amt, err := ParseAmount(s)
+	if err != nil {
+		return err
+	}
+	*a = amt
+	return nil
+}
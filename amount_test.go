@@ -0,0 +1,93 @@
+package aplos
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Amount
+		wantErr bool
+	}{
+		{in: "0", want: 0},
+		{in: "12", want: 12_00},
+		{in: "1234.5", want: 1234_50},
+		{in: "-1234.56", want: -1234_56},
+		{in: ".25", want: 25},
+		{in: "0.1", want: 10},
+		{in: "1.500", want: 1_50},
+		{in: "1.2e3", want: 1200_00},
+		{in: "1.005", wantErr: true},
+		{in: "", wantErr: true},
+		{in: "$12", wantErr: true},
+		{in: "1,234.56", wantErr: true},
+		{in: "1e30", wantErr: true},
+	}
+	for _, test := range tests {
+		got, err := ParseAmount(test.in)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("ParseAmount(%q) = %v, want an error", test.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseAmount(%q): %v", test.in, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("ParseAmount(%q) = %d cents, want %d", test.in, got, test.want)
+		}
+	}
+}
+
+func TestAmountString(t *testing.T) {
+	tests := []struct {
+		in   Amount
+		want string
+	}{
+		{0, "0.00"},
+		{5, "0.05"},
+		{-5, "-0.05"},
+		{1234_50, "1234.50"},
+		{-1234_56, "-1234.56"},
+		{NewAmount(0.1 + 0.2), "0.30"},
+		{NewAmount(-19.999), "-20.00"},
+	}
+	for _, test := range tests {
+		if got := test.in.String(); got != test.want {
+			t.Errorf("Amount(%d).String() = %q, want %q", int64(test.in), got, test.want)
+		}
+	}
+}
+
+func TestAmountJSON(t *testing.T) {
+	var line struct {
+		Amount Amount `json:"amount"`
+	}
+	for _, in := range []string{`{"amount": 125.1}`, `{"amount": "125.10"}`, `{"amount": 12510e-2}`} {
+		line.Amount = 0
+		if err := json.Unmarshal([]byte(in), &line); err != nil {
+			t.Fatalf("failed to unmarshal %s: %v", in, err)
+		}
+		if line.Amount != 125_10 {
+			t.Errorf("unmarshalled %s as %d cents, want 12510", in, line.Amount)
+		}
+	}
+
+	out, err := json.Marshal(line)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	if got, want := string(out), `{"amount":125.10}`; got != want {
+		t.Errorf("marshalled %s, want %s", got, want)
+	}
+
+	for _, in := range []string{`{"amount": 0.001}`, `{"amount": ""}`, `{"amount": true}`} {
+		if err := json.Unmarshal([]byte(in), &line); err == nil {
+			t.Errorf("unmarshalling %s returned no error", in)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package aplos
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// AnnotationsHeader is the request header annotations are sent in, encoded as
+// a URL query string, e.g. "job=nightly-sync&user=alice". Aplos ignores it, but
+// it's visible to proxies, logging transports, and anything else in the request
+// path, allowing API traffic to be attributed to the workflow that caused it.
+const AnnotationsHeader = "X-Request-Annotations"
+
+type annotationsKey struct{}
+
+// WithAnnotation returns a copy of ctx annotated with the given key and value,
+// like a job name, user, or reason. All requests the Client makes with the
+// returned context carry the annotations in the AnnotationsHeader. Annotating a
+// key that's already set replaces its value.
+func WithAnnotation(ctx context.Context, key, value string) context.Context {
+	prev := Annotations(ctx)
+	next := make(map[string]string, len(prev)+1)
+	for k, v := range prev {
+		next[k] = v
+	}
+	next[key] = value
+	return context.WithValue(ctx, annotationsKey{}, next)
+}
+
+// Annotations returns the annotations attached to ctx with WithAnnotation, or
+// nil if there are none. The returned map must not be modified.
+func Annotations(ctx context.Context) map[string]string {
+	a, _ := ctx.Value(annotationsKey{}).(map[string]string)
+	return a
+}
+
+func setAnnotationsHeader(req *http.Request) {
+	a := Annotations(req.Context())
+	if len(a) == 0 {
+		return
+	}
+	q := url.Values{}
+	for k, v := range a {
+		q.Set(k, v)
+	}
+	req.Header.Set(AnnotationsHeader, q.Encode())
+}
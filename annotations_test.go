@@ -0,0 +1,38 @@
+package aplos
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestAnnotations(t *testing.T) {
+	ctx := context.Background()
+	if got := Annotations(ctx); got != nil {
+		t.Errorf("Annotations() = %v, want nil", got)
+	}
+
+	parent := WithAnnotation(ctx, "job", "nightly-sync")
+	child := WithAnnotation(WithAnnotation(parent, "user", "alice"), "job", "backfill")
+
+	if got, want := Annotations(parent), map[string]string{"job": "nightly-sync"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("parent Annotations() = %v, want %v", got, want)
+	}
+	if got, want := Annotations(child), map[string]string{"job": "backfill", "user": "alice"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("child Annotations() = %v, want %v", got, want)
+	}
+
+	var gotHeader string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Get(AnnotationsHeader)
+		fmt.Fprint(w, `{"status": 200, "data": {"transaction": {"id": 1}}}`)
+	}))
+	if _, err := c.Transaction(WithAnnotation(child, "reason", "month end"), 1); err != nil {
+		t.Fatalf("Transaction: %v", err)
+	}
+	if want := "job=backfill&reason=month+end&user=alice"; gotHeader != want {
+		t.Errorf("%s = %q, want %q", AnnotationsHeader, gotHeader, want)
+	}
+}
@@ -0,0 +1,198 @@
+// Package aplostest provides a fake Aplos API server for end-to-end tests of
+// code built on the aplos package. The fake implements the real authentication
+// handshake with synthetic credentials, so tests exercise the same auth code
+// path as production.
+package aplostest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Silicon-Ally/aplos"
+)
+
+// BasePath is the path the fake serves the API under, mirroring the real API.
+const BasePath = "/hermes/api/v1"
+
+// Credentials are a synthetic API key, like one downloaded from the Aplos UI.
+type Credentials struct {
+	ClientID string
+	Key      *rsa.PrivateKey
+}
+
+// NewCredentials mints a random client ID and RSA key pair.
+func NewCredentials() (*Credentials, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	id, err := randomHex(16)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate client ID: %w", err)
+	}
+	return &Credentials{ClientID: id, Key: key}, nil
+}
+
+// KeyFile returns the private key in the format of a key file downloaded from
+// the Aplos UI, i.e. base64-encoded PKCS8, for testing code that loads keys
+// with aplos.LoadPrivateKeyFromFile.
+func (c *Credentials) KeyFile() ([]byte, error) {
+	dat, err := x509.MarshalPKCS8PrivateKey(c.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal key: %w", err)
+	}
+	return []byte(base64.StdEncoding.EncodeToString(dat)), nil
+}
+
+// Server is a fake Aplos API. Requests other than authentication must carry a
+// token issued by the server, or they fail with a 401, like the real API.
+type Server struct {
+	// URL is the base URL of the fake API, for use with aplos.WithBaseURL.
+	URL string
+	// Credentials are the only credentials the server accepts.
+	Credentials *Credentials
+	// TokenTTL is how long issued tokens are valid for, an hour by default.
+	TokenTTL time.Duration
+	// PageSize is the number of records per page in list responses,
+	// DefaultPageSize if unset.
+	PageSize int
+
+	srv *httptest.Server
+	mux *http.ServeMux
+
+	mu     sync.Mutex
+	tokens map[string]time.Time
+	data   *Dataset
+	loaded bool
+
+	// faultMux matches requests to the patterns in faults.
+	faultMux *http.ServeMux
+	faults   map[string][]*Fault
+}
+
+// NewServer starts a fake Aplos API with freshly minted credentials. Callers
+// should call Close when done. Like httptest.NewServer, it panics if the
+// server can't be started.
+func NewServer() *Server {
+	creds, err := NewCredentials()
+	if err != nil {
+		panic(fmt.Sprintf("aplostest: failed to create credentials: %v", err))
+	}
+	s := &Server{
+		Credentials: creds,
+		TokenTTL:    time.Hour,
+		mux:         http.NewServeMux(),
+		tokens:      make(map[string]time.Time),
+	}
+	s.mux.HandleFunc("GET "+BasePath+"/auth/{client_id}", s.handleAuth)
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.srv.URL + BasePath
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Client returns an aplos.Client for the server, authenticated with the
+// server's credentials. opts are applied after the option setting the base
+// URL.
+func (s *Server) Client(opts ...aplos.Option) (*aplos.Client, error) {
+	opts = append([]aplos.Option{aplos.WithBaseURL(s.URL)}, opts...)
+	return aplos.New(s.Credentials.ClientID, s.Credentials.Key, opts...)
+}
+
+// Handle registers a handler for requests matching pattern, which is relative
+// to the API base path, e.g. "GET /funds/{id}". Handlers are only called for
+// requests with a valid token.
+func (s *Server) Handle(pattern string, h http.Handler) {
+	s.mux.Handle(withBasePath(pattern), h)
+}
+
+// HandleFunc is like Handle, for handler functions.
+func (s *Server) HandleFunc(pattern string, h func(http.ResponseWriter, *http.Request)) {
+	s.Handle(pattern, http.HandlerFunc(h))
+}
+
+// withBasePath prefixes the path in a ServeMux pattern with BasePath.
+func withBasePath(pattern string) string {
+	method, path, ok := strings.Cut(pattern, " ")
+	if !ok {
+		return BasePath + pattern
+	}
+	return method + " " + BasePath + path
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if f := s.takeFault(r); f != nil && s.serveFault(w, r, f) {
+		return
+	}
+	s.serve(w, r)
+}
+
+// serve responds to r, without any injected faults.
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, BasePath+"/auth/") && !s.validToken(r) {
+		WriteError(w, http.StatusUnauthorized, "a valid access token is required")
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) validToken(r *http.Request) bool {
+	tkn, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	exp, ok := s.tokens[tkn]
+	return ok && time.Now().Before(exp)
+}
+
+// handleAuth implements the authentication handshake: it issues a new token,
+// encrypted with the client's public key.
+func (s *Server) handleAuth(w http.ResponseWriter, r *http.Request) {
+	if r.PathValue("client_id") != s.Credentials.ClientID {
+		WriteError(w, http.StatusUnauthorized, "unknown client ID")
+		return
+	}
+	tkn, err := randomHex(32)
+	if err != nil {
+		WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	enc, err := rsa.EncryptPKCS1v15(rand.Reader, &s.Credentials.Key.PublicKey, []byte(tkn))
+	if err != nil {
+		WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	exp := time.Now().Add(s.TokenTTL)
+
+	s.mu.Lock()
+	s.tokens[tkn] = exp
+	s.mu.Unlock()
+
+	WriteData(w, map[string]string{
+		"expires": exp.Format("2006-01-02T15:04:05.000-0700"),
+		"token":   base64.StdEncoding.EncodeToString(enc),
+	})
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,97 @@
+package aplostest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Silicon-Ally/aplos"
+	"github.com/Silicon-Ally/aplos/aplostest"
+)
+
+func TestServer(t *testing.T) {
+	srv := aplostest.NewServer()
+	defer srv.Close()
+	srv.HandleFunc("GET /funds/{id}", func(w http.ResponseWriter, r *http.Request) {
+		aplostest.WriteData(w, map[string]interface{}{
+			"fund": map[string]interface{}{"id": 1, "name": "General"},
+		})
+	})
+
+	c, err := srv.Client()
+	if err != nil {
+		t.Fatalf("Client: %v", err)
+	}
+	fund, err := c.Fund(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Fund: %v", err)
+	}
+	if fund.Name != "General" {
+		t.Errorf("Fund() = %+v, want the General fund", fund)
+	}
+}
+
+func TestServerRejectsBadCredentials(t *testing.T) {
+	srv := aplostest.NewServer()
+	defer srv.Close()
+
+	other, err := aplostest.NewCredentials()
+	if err != nil {
+		t.Fatalf("NewCredentials: %v", err)
+	}
+	if _, err := aplos.New(other.ClientID, srv.Credentials.Key, aplos.WithBaseURL(srv.URL)); !errors.Is(err, aplos.ErrUnauthorized) {
+		t.Errorf("New with an unknown client ID = %v, want ErrUnauthorized", err)
+	}
+	if _, err := aplos.New(srv.Credentials.ClientID, other.Key, aplos.WithBaseURL(srv.URL)); err == nil {
+		t.Error("New with the wrong key returned no error, want one")
+	}
+
+	resp, err := http.Get(srv.URL + "/funds")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("unauthenticated request status = %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+}
+
+func TestCredentialsKeyFile(t *testing.T) {
+	creds, err := aplostest.NewCredentials()
+	if err != nil {
+		t.Fatalf("NewCredentials: %v", err)
+	}
+	dat, err := creds.KeyFile()
+	if err != nil {
+		t.Fatalf("KeyFile: %v", err)
+	}
+	fp := filepath.Join(t.TempDir(), "key")
+	if err := os.WriteFile(fp, dat, 0o600); err != nil {
+		t.Fatalf("failed to write key file: %v", err)
+	}
+	key, err := aplos.LoadPrivateKeyFromFile(fp)
+	if err != nil {
+		t.Fatalf("LoadPrivateKeyFromFile: %v", err)
+	}
+	if !key.Equal(creds.Key) {
+		t.Error("loaded key doesn't match the minted one")
+	}
+}
+
+func TestServerUnauthorizedIsAPIError(t *testing.T) {
+	srv := aplostest.NewServer()
+	defer srv.Close()
+	srv.TokenTTL = -1
+
+	c, err := srv.Client()
+	if err != nil {
+		t.Fatalf("Client: %v", err)
+	}
+	var apiErr *aplos.APIError
+	if _, err := c.Funds(context.Background()); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Funds() with an expired token = %v, want a 401", err)
+	}
+}
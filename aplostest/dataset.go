@@ -0,0 +1,195 @@
+package aplostest
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/Silicon-Ally/aplos"
+)
+
+// Dataset is the data served by a Server, see Server.Load.
+type Dataset struct {
+	Funds         []aplos.Fund
+	Accounts      []aplos.Account
+	Contacts      []aplos.Contact
+	Purposes      []aplos.Purpose
+	Contributions []aplos.Contribution
+	// Transactions should include their lines, which are served by the single
+	// transaction endpoint and stripped from listings, like the real API.
+	Transactions []aplos.Transaction
+}
+
+// DefaultPageSize is the number of records per page in list responses, unless
+// Server.PageSize is set.
+const DefaultPageSize = 100
+
+// Load makes the server serve d from the read endpoints for funds, accounts,
+// contacts, purposes, contributions, and transactions, replacing any
+// previously loaded dataset. Listings are paginated, and transaction listings
+// support the date range, account number, and contact filters. Tests
+// shouldn't register their own handlers for these endpoints with Handle.
+func (s *Server) Load(d *Dataset) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data = d
+	if s.loaded {
+		return
+	}
+	s.loaded = true
+
+	s.listHandler("/funds", "funds", func(d *Dataset, _ url.Values) []interface{} { return toAny(d.Funds) })
+	s.getHandler("/funds/{id}", "fund", func(d *Dataset, id int) (interface{}, bool) {
+		return find(d.Funds, func(f aplos.Fund) bool { return f.ID == id })
+	})
+	s.listHandler("/accounts", "accounts", func(d *Dataset, _ url.Values) []interface{} { return toAny(d.Accounts) })
+	s.getHandler("/accounts/{id}", "account", func(d *Dataset, n int) (interface{}, bool) {
+		return find(d.Accounts, func(a aplos.Account) bool { return a.AccountNumber == n })
+	})
+	s.listHandler("/contacts", "contacts", func(d *Dataset, _ url.Values) []interface{} { return toAny(d.Contacts) })
+	s.getHandler("/contacts/{id}", "contact", func(d *Dataset, id int) (interface{}, bool) {
+		return find(d.Contacts, func(c aplos.Contact) bool { return c.ID == id })
+	})
+	s.listHandler("/purposes", "purposes", func(d *Dataset, _ url.Values) []interface{} { return toAny(d.Purposes) })
+	s.getHandler("/purposes/{id}", "purpose", func(d *Dataset, id int) (interface{}, bool) {
+		return find(d.Purposes, func(p aplos.Purpose) bool { return p.ID == id })
+	})
+	s.listHandler("/contributions", "contributions", func(d *Dataset, q url.Values) []interface{} {
+		var out []interface{}
+		for _, c := range d.Contributions {
+			if inRange(c.Date, q) {
+				out = append(out, c)
+			}
+		}
+		return out
+	})
+	s.getHandler("/contributions/{id}", "contribution", func(d *Dataset, id int) (interface{}, bool) {
+		return find(d.Contributions, func(c aplos.Contribution) bool { return c.ID == id })
+	})
+	s.listHandler("/transactions", "transactions", func(d *Dataset, q url.Values) []interface{} {
+		var out []interface{}
+		for _, t := range d.Transactions {
+			if matchesTransaction(t, q) {
+				t.Lines = nil
+				out = append(out, t)
+			}
+		}
+		return out
+	})
+	s.getHandler("/transactions/{id}", "transaction", func(d *Dataset, id int) (interface{}, bool) {
+		return find(d.Transactions, func(t aplos.Transaction) bool { return t.ID == id })
+	})
+}
+
+func (s *Server) dataset() *Dataset {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.data
+}
+
+// listHandler serves a paginated list of the records returned by list, under
+// the given key in the response data.
+func (s *Server) listHandler(path, key string, list func(*Dataset, url.Values) []interface{}) {
+	s.HandleFunc("GET "+path, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		records := list(s.dataset(), q)
+
+		size := s.PageSize
+		if size <= 0 {
+			size = DefaultPageSize
+		}
+		page := 1
+		if p, err := strconv.Atoi(q.Get("page")); err == nil && p > 0 {
+			page = p
+		}
+		pageCount := (len(records) + size - 1) / size
+		start := min((page-1)*size, len(records))
+		end := min(start+size, len(records))
+
+		links := map[string]string{}
+		if page < pageCount {
+			q.Set("page", strconv.Itoa(page+1))
+			links["next"] = r.URL.Path + "?" + q.Encode()
+		}
+		pageRecords := records[start:end]
+		if pageRecords == nil {
+			pageRecords = []interface{}{}
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"version": "0.0.1",
+			"status":  http.StatusOK,
+			"links":   links,
+			"meta": map[string]int{
+				"record_count": len(records),
+				"page_count":   pageCount,
+				"page_num":     page,
+			},
+			"data": map[string]interface{}{key: pageRecords},
+		})
+	})
+}
+
+// getHandler serves the record returned by get for the numeric ID in the path,
+// under the given key in the response data.
+func (s *Server) getHandler(path, key string, get func(*Dataset, int) (interface{}, bool)) {
+	s.HandleFunc("GET "+path, func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, "invalid ID")
+			return
+		}
+		v, ok := get(s.dataset(), id)
+		if !ok {
+			WriteError(w, http.StatusNotFound, "not found")
+			return
+		}
+		WriteData(w, map[string]interface{}{key: v})
+	})
+}
+
+func matchesTransaction(t aplos.Transaction, q url.Values) bool {
+	if !inRange(t.Date, q) {
+		return false
+	}
+	if v := q.Get("f_contact"); v != "" && (t.Contact == nil || strconv.Itoa(t.Contact.ID) != v) {
+		return false
+	}
+	if v := q.Get("f_accountnumber"); v != "" {
+		for _, l := range t.Lines {
+			if strconv.Itoa(l.Account.AccountNumber) == v {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// inRange reports whether d is within the f_rangestart and f_rangeend filters
+// in q, if any. Dates are compared in their YYYY-MM-DD form.
+func inRange(d aplos.Date, q url.Values) bool {
+	if start := q.Get("f_rangestart"); start != "" && d.String() < start {
+		return false
+	}
+	if end := q.Get("f_rangeend"); end != "" && d.String() > end {
+		return false
+	}
+	return true
+}
+
+func toAny[T any](xs []T) []interface{} {
+	out := make([]interface{}, len(xs))
+	for i, x := range xs {
+		out[i] = x
+	}
+	return out
+}
+
+func find[T any](xs []T, match func(T) bool) (interface{}, bool) {
+	for _, x := range xs {
+		if match(x) {
+			return x, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,127 @@
+package aplostest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fault is a failure to inject into the server's responses, see Server.Inject.
+// Fields can be combined, e.g. a Delay before a Status.
+type Fault struct {
+	// Status, if set, is the status code to respond with instead of the real
+	// response, e.g. 429 or 500.
+	Status int
+	// RetryAfter, if set, is sent as the Retry-After header, in seconds, with
+	// Status.
+	RetryAfter int
+	// Delay is how long to wait before responding, e.g. to trigger client
+	// timeouts. The wait ends early if the client gives up on the request.
+	Delay time.Duration
+	// Truncate cuts the real response body in half, so the client gets invalid
+	// JSON.
+	Truncate bool
+	// ExpireToken revokes the token the request was made with and responds
+	// with a 401, as if the token had expired.
+	ExpireToken bool
+
+	// Times is how many requests the fault applies to before it's removed.
+	// Zero means it applies until ClearFaults is called.
+	Times int
+}
+
+// Inject makes requests matching pattern fail with f, so that retry, backoff,
+// and re-auth logic can be tested deterministically. Patterns are like those
+// for Handle, e.g. "GET /funds/{id}", and "/" matches every request, including
+// authentication requests. If several faults are injected for the same
+// pattern, they apply in order, each for its number of Times.
+func (s *Server) Inject(pattern string, f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.faultMux == nil {
+		s.faultMux = http.NewServeMux()
+		s.faults = make(map[string][]*Fault)
+	}
+	full := withBasePath(pattern)
+	if _, ok := s.faults[full]; !ok {
+		s.faultMux.Handle(full, http.NotFoundHandler())
+	}
+	s.faults[full] = append(s.faults[full], &f)
+}
+
+// ClearFaults removes all injected faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for k := range s.faults {
+		s.faults[k] = nil
+	}
+}
+
+// takeFault returns the fault to apply to r, if any, and counts it against the
+// fault's Times.
+func (s *Server) takeFault(r *http.Request) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.faultMux == nil {
+		return nil
+	}
+	_, pattern := s.faultMux.Handler(r)
+	queue := s.faults[pattern]
+	if len(queue) == 0 {
+		return nil
+	}
+	f := *queue[0]
+	if queue[0].Times > 0 {
+		queue[0].Times--
+		if queue[0].Times == 0 {
+			s.faults[pattern] = queue[1:]
+		}
+	}
+	return &f
+}
+
+// serveFault responds to r with fault f applied, and reports whether the
+// response has been written.
+func (s *Server) serveFault(w http.ResponseWriter, r *http.Request, f *Fault) bool {
+	if f.Delay > 0 {
+		t := time.NewTimer(f.Delay)
+		defer t.Stop()
+		select {
+		case <-t.C:
+		case <-r.Context().Done():
+			return true
+		}
+	}
+	if f.ExpireToken {
+		if tkn, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+			s.mu.Lock()
+			delete(s.tokens, tkn)
+			s.mu.Unlock()
+		}
+		WriteError(w, http.StatusUnauthorized, "the access token has expired")
+		return true
+	}
+	if f.Status != 0 {
+		if f.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(f.RetryAfter))
+		}
+		WriteError(w, f.Status, http.StatusText(f.Status))
+		return true
+	}
+	if f.Truncate {
+		rec := httptest.NewRecorder()
+		s.serve(rec, r)
+		for k, vs := range rec.Header() {
+			w.Header()[k] = vs
+		}
+		w.WriteHeader(rec.Code)
+		body := rec.Body.Bytes()
+		w.Write(body[:len(body)/2])
+		return true
+	}
+	return false
+}
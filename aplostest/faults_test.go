@@ -0,0 +1,97 @@
+package aplostest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Silicon-Ally/aplos"
+	"github.com/Silicon-Ally/aplos/aplostest"
+)
+
+func TestInject(t *testing.T) {
+	srv := aplostest.NewServer()
+	defer srv.Close()
+	srv.Load(aplostest.SmallNonprofit())
+	c, err := srv.Client()
+	if err != nil {
+		t.Fatalf("Client: %v", err)
+	}
+	ctx := context.Background()
+
+	srv.Inject("GET /funds/{id}", aplostest.Fault{Status: http.StatusTooManyRequests, RetryAfter: 30, Times: 1})
+	srv.Inject("GET /funds/{id}", aplostest.Fault{Status: http.StatusInternalServerError, Times: 1})
+
+	var apiErr *aplos.APIError
+	_, err = c.Fund(ctx, 1)
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("first Fund() = %v, want a 429", err)
+	}
+	if d, ok := apiErr.RetryAfter(); !ok || d != 30*time.Second {
+		t.Errorf("RetryAfter() = %v, %t, want 30s", d, ok)
+	}
+	if _, err := c.Fund(ctx, 1); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("second Fund() = %v, want a 500", err)
+	}
+	if _, err := c.Fund(ctx, 1); err != nil {
+		t.Errorf("third Fund() = %v, want the faults to be used up", err)
+	}
+	// Other endpoints aren't affected.
+	if _, err := c.Funds(ctx); err != nil {
+		t.Errorf("Funds() = %v, want no error", err)
+	}
+
+	srv.Inject("/", aplostest.Fault{Truncate: true})
+	if _, err := c.Funds(ctx); err == nil {
+		t.Error("Funds() with a truncated response returned no error, want one")
+	}
+	srv.ClearFaults()
+	if _, err := c.Funds(ctx); err != nil {
+		t.Errorf("Funds() after ClearFaults = %v, want no error", err)
+	}
+}
+
+func TestInjectDelay(t *testing.T) {
+	srv := aplostest.NewServer()
+	defer srv.Close()
+	srv.Load(aplostest.SmallNonprofit())
+	c, err := srv.Client()
+	if err != nil {
+		t.Fatalf("Client: %v", err)
+	}
+
+	srv.Inject("GET /accounts", aplostest.Fault{Delay: time.Minute})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := c.Accounts(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Accounts() = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestInjectExpireToken(t *testing.T) {
+	srv := aplostest.NewServer()
+	defer srv.Close()
+	srv.Load(aplostest.SmallNonprofit())
+	c, err := srv.Client()
+	if err != nil {
+		t.Fatalf("Client: %v", err)
+	}
+
+	// The client authenticates again and resends the request once, which also
+	// fails.
+	srv.Inject("GET /funds", aplostest.Fault{ExpireToken: true, Times: 2})
+	var apiErr *aplos.APIError
+	if _, err := c.Funds(context.Background()); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Funds() = %v, want a 401", err)
+	}
+	// The token stays revoked for other endpoints, so the client authenticates
+	// again.
+	if _, err := c.Accounts(context.Background()); err != nil {
+		t.Errorf("Accounts() after the token expired = %v, want no error", err)
+	}
+	if got, want := c.Stats().Endpoints["GET /auth/{client_id}"].Requests, 3; got != want {
+		t.Errorf("auth requests = %d, want %d", got, want)
+	}
+}
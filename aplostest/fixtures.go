@@ -0,0 +1,316 @@
+package aplostest
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/Silicon-Ally/aplos"
+)
+
+// Account numbers in the chart of accounts shared by the fixture datasets.
+const (
+	AccountChecking          = 1000
+	AccountSavings           = 1010
+	AccountUndeposited       = 1200
+	AccountPayable           = 2000
+	AccountNetAssets         = 3000
+	AccountContributions     = 4000
+	AccountGrants            = 4100
+	AccountProgramRevenue    = 4200
+	AccountSalaries          = 5000
+	AccountRent              = 5100
+	AccountSupplies          = 5200
+	AccountProgramExpenses   = 5300
+	AccountAdministrativeFee = 5400
+)
+
+// SmallNonprofit returns a dataset for a small nonprofit with three funds, a
+// couple dozen donors, and a year (2023) of monthly giving, payroll, and bills.
+func SmallNonprofit() *Dataset {
+	b := newFixtureBuilder(1, []string{"General", "Youth Program", "Building"})
+	donors := b.contacts(24, 2)
+	start := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for m := 0; m < 12; m++ {
+		month := start.AddDate(0, m, 0)
+		b.giving(month.AddDate(0, 0, 14), donors, 0.5, 25, 500)
+		b.expense(month, "Rent", &b.vendors[0], AccountRent, 0, 1800)
+		b.expense(month.AddDate(0, 0, 14), "Payroll", nil, AccountSalaries, 0, 6200)
+		b.expense(month.AddDate(0, 0, 20), "Program supplies", &b.vendors[1], AccountProgramExpenses, 1, 150+float64(b.rng.IntN(400)))
+	}
+	return b.d
+}
+
+// LargeChurch returns a dataset for a large church with four funds, 150 giving
+// households, and two years (2022 and 2023) of weekly offerings, biweekly
+// payroll, and monthly bills.
+func LargeChurch() *Dataset {
+	b := newFixtureBuilder(2, []string{"General", "Missions", "Building", "Benevolence"})
+	donors := b.contacts(150, 4)
+	start := time.Date(2022, time.January, 2, 0, 0, 0, 0, time.UTC) // A Sunday.
+	for w := 0; w < 104; w++ {
+		sunday := start.AddDate(0, 0, 7*w)
+		b.giving(sunday, donors, 0.35, 20, 1000)
+		if w%2 == 0 {
+			b.expense(sunday.AddDate(0, 0, 5), "Payroll", nil, AccountSalaries, 0, 28500)
+		}
+		if sunday.Day() <= 7 {
+			b.expense(sunday, "Mortgage", &b.vendors[0], AccountRent, 2, 9200)
+			b.expense(sunday.AddDate(0, 0, 3), "Utilities", &b.vendors[1], AccountSupplies, 0, 1500+float64(b.rng.IntN(1500)))
+			b.expense(sunday.AddDate(0, 0, 10), "Missions support", &b.vendors[2], AccountProgramExpenses, 1, 4000)
+		}
+	}
+	return b.d
+}
+
+// FiscalSponsor returns a dataset for a fiscal sponsor with ten funds, a
+// general fund and nine sponsored projects, each with grant income, project
+// expenses, and a monthly administrative fee to the general fund, for 2023.
+func FiscalSponsor() *Dataset {
+	funds := []string{"General"}
+	for i := 1; i <= 9; i++ {
+		funds = append(funds, fmt.Sprintf("Project %d", i))
+	}
+	b := newFixtureBuilder(3, funds)
+	funders := b.contacts(12, 3)
+	start := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for p := 1; p <= 9; p++ {
+		grant := float64(20000 + b.rng.IntN(80000))
+		funder := funders[b.rng.IntN(len(funders))]
+		b.txn(start.AddDate(0, 0, p), fmt.Sprintf("Grant from %s", contactName(funder)), &funder,
+			b.line(AccountChecking, p, aplos.NewAmount(grant)),
+			b.line(AccountGrants, p, -aplos.NewAmount(grant)),
+		)
+		for m := 0; m < 12; m++ {
+			month := start.AddDate(0, m, 0)
+			b.expense(month.AddDate(0, 0, 10+p), "Project expenses", &b.vendors[p%len(b.vendors)], AccountProgramExpenses, p, math.Round(grant/24))
+			fee := aplos.NewAmount(grant * 0.1 / 12)
+			b.txn(month.AddDate(0, 0, 27), "Administrative fee", nil,
+				b.line(AccountAdministrativeFee, p, fee),
+				b.line(AccountProgramRevenue, 0, -fee),
+			)
+		}
+	}
+	b.giving(start.AddDate(0, 11, 15), funders, 1, 100, 5000)
+	return b.d
+}
+
+type fixtureBuilder struct {
+	d       *Dataset
+	rng     *rand.Rand
+	vendors []aplos.Contact
+	// purposes[i] is the purpose whose default fund is d.Funds[i].
+	purposes []aplos.Purpose
+	// pending are contributions not yet deposited, and batches is the number
+	// of deposit batches so far.
+	pending []aplos.Contribution
+	batches int
+}
+
+func newFixtureBuilder(seed uint64, funds []string) *fixtureBuilder {
+	b := &fixtureBuilder{
+		d:   &Dataset{Accounts: chartOfAccounts()},
+		rng: rand.New(rand.NewPCG(seed, seed)),
+	}
+	for i, name := range funds {
+		f := aplos.Fund{ID: i + 1, Name: name, IsEnabled: true}
+		b.d.Funds = append(b.d.Funds, f)
+		p := aplos.Purpose{ID: i + 1, Name: name, IsEnabled: true, Fund: &f}
+		b.d.Purposes = append(b.d.Purposes, p)
+		b.purposes = append(b.purposes, p)
+	}
+	for _, name := range []string{"Main Street Properties", "Office Depot", "City Utilities", "Global Missions Partners"} {
+		b.vendors = append(b.vendors, b.addContact(aplos.Contact{Type: aplos.ContactTypeCompany, CompanyName: name}))
+	}
+	return b
+}
+
+var (
+	firstNames = []string{"Ada", "Ben", "Carmen", "Dev", "Elena", "Farid", "Grace", "Hiro", "Imani", "Jonas", "Kira", "Luis", "Maya", "Nate", "Olga", "Priya"}
+	lastNames  = []string{"Alvarez", "Brooks", "Chen", "Diallo", "Evans", "Fischer", "Garcia", "Haddad", "Ito", "Johnson", "Kowalski", "Lee", "Morales", "Nguyen", "Okafor", "Patel"}
+)
+
+// contacts adds n individual donors, every companyEvery'th of which is a
+// company instead.
+func (b *fixtureBuilder) contacts(n, companyEvery int) []aplos.Contact {
+	var out []aplos.Contact
+	for i := 0; i < n; i++ {
+		first, last := firstNames[b.rng.IntN(len(firstNames))], lastNames[b.rng.IntN(len(lastNames))]
+		c := aplos.Contact{
+			Type:      aplos.ContactTypeIndividual,
+			FirstName: first,
+			LastName:  last,
+			Email:     fmt.Sprintf("%s.%s%d@example.com", lowerASCII(first), lowerASCII(last), i),
+		}
+		if companyEvery > 0 && i%(companyEvery*5) == companyEvery*5-1 {
+			c = aplos.Contact{Type: aplos.ContactTypeCompany, CompanyName: fmt.Sprintf("%s Family Foundation", last)}
+		}
+		out = append(out, b.addContact(c))
+	}
+	return out
+}
+
+func (b *fixtureBuilder) addContact(c aplos.Contact) aplos.Contact {
+	c.ID = len(b.d.Contacts) + 1
+	b.d.Contacts = append(b.d.Contacts, c)
+	return c
+}
+
+// giving records contributions on the given date from each donor with
+// probability p, for amounts between lo and hi, and deposits them.
+func (b *fixtureBuilder) giving(t time.Time, donors []aplos.Contact, p float64, lo, hi int) {
+	for _, donor := range donors {
+		if b.rng.Float64() < p {
+			b.gift(t, donor, float64(lo+5*b.rng.IntN((hi-lo)/5+1)))
+		}
+	}
+	b.deposit(t)
+}
+
+// gift adds a contribution from donor, to be recorded by the next deposit.
+func (b *fixtureBuilder) gift(t time.Time, donor aplos.Contact, amt float64) {
+	// Most giving goes to the first, general purpose.
+	purpose := b.purposes[0]
+	if b.rng.IntN(4) == 0 {
+		purpose = b.purposes[b.rng.IntN(len(b.purposes))]
+	}
+	c := aplos.Contribution{
+		Date:          toDate(t),
+		Amount:        aplos.NewAmount(amt),
+		PaymentMethod: aplos.PaymentMethodCheck,
+		Contact:       donor,
+		Purpose:       purpose,
+	}
+	if b.rng.IntN(3) == 0 {
+		c.PaymentMethod = aplos.PaymentMethodCreditCard
+	} else {
+		c.CheckNumber = fmt.Sprint(1000 + b.rng.IntN(9000))
+	}
+	b.pending = append(b.pending, c)
+}
+
+// deposit records the pending contributions in a deposited batch, and a
+// deposit transaction for their total per fund.
+func (b *fixtureBuilder) deposit(t time.Time) {
+	if len(b.pending) == 0 {
+		return
+	}
+	date := toDate(t)
+	b.batches++
+	batch := &aplos.ContributionBatch{ID: b.batches, Name: "Deposit " + date.String(), Date: date, Deposited: true}
+	byFund := make([]aplos.Amount, len(b.d.Funds))
+	for _, c := range b.pending {
+		c.ID = len(b.d.Contributions) + 1
+		c.Batch = batch
+		b.d.Contributions = append(b.d.Contributions, c)
+		byFund[c.Purpose.Fund.ID-1] += c.Amount
+	}
+	b.pending = nil
+
+	var lines []aplos.TransactionLine
+	for i, amt := range byFund {
+		if amt != 0 {
+			lines = append(lines, b.line(AccountChecking, i, amt), b.line(AccountContributions, i, -amt))
+		}
+	}
+	b.txn(t, batch.Name, nil, lines...)
+}
+
+// expense records a payment from checking to the given expense account and
+// fund, by index into the dataset's funds.
+func (b *fixtureBuilder) expense(t time.Time, memo string, payee *aplos.Contact, account, fund int, amt float64) {
+	a := aplos.NewAmount(amt)
+	b.txn(t, memo, payee, b.line(account, fund, a), b.line(AccountChecking, fund, -a))
+}
+
+func (b *fixtureBuilder) line(account, fund int, amt aplos.Amount) aplos.TransactionLine {
+	var acct aplos.Account
+	for _, a := range b.d.Accounts {
+		if a.AccountNumber == account {
+			acct = aplos.Account{AccountNumber: a.AccountNumber, Name: a.Name}
+		}
+	}
+	f := b.d.Funds[fund]
+	return aplos.TransactionLine{Amount: amt, Account: acct, Fund: aplos.Fund{ID: f.ID, Name: f.Name}}
+}
+
+func (b *fixtureBuilder) txn(t time.Time, memo string, contact *aplos.Contact, lines ...aplos.TransactionLine) {
+	txn := aplos.Transaction{
+		ID:      len(b.d.Transactions) + 1,
+		Memo:    memo,
+		Date:    toDate(t),
+		Created: aplos.Time{Time: t.Add(9 * time.Hour)},
+		Contact: contact,
+	}
+	for i, l := range lines {
+		l.ID = txn.ID*100 + i
+		if l.Amount > 0 {
+			txn.Amount += l.Amount
+		}
+		txn.Lines = append(txn.Lines, l)
+	}
+	b.d.Transactions = append(b.d.Transactions, txn)
+}
+
+func chartOfAccounts() []aplos.Account {
+	groups := map[string]*aplos.AccountGroup{
+		"asset":     {ID: 1, Name: "Cash and Equivalents", Seq: 10},
+		"liability": {ID: 2, Name: "Current Liabilities", Seq: 20},
+		"equity":    {ID: 3, Name: "Net Assets", Seq: 30},
+		"income":    {ID: 4, Name: "Support and Revenue", Seq: 40},
+		"expense":   {ID: 5, Name: "Expenses", Seq: 50},
+	}
+	accts := []struct {
+		number   int
+		name     string
+		category string
+	}{
+		{AccountChecking, "Checking", "asset"},
+		{AccountSavings, "Savings", "asset"},
+		{AccountUndeposited, "Undeposited Funds", "asset"},
+		{AccountPayable, "Accounts Payable", "liability"},
+		{AccountNetAssets, "Net Assets", "equity"},
+		{AccountContributions, "Contributions", "income"},
+		{AccountGrants, "Grants", "income"},
+		{AccountProgramRevenue, "Program Revenue", "income"},
+		{AccountSalaries, "Salaries", "expense"},
+		{AccountRent, "Rent", "expense"},
+		{AccountSupplies, "Supplies and Utilities", "expense"},
+		{AccountProgramExpenses, "Program Expenses", "expense"},
+		{AccountAdministrativeFee, "Administrative Fees", "expense"},
+	}
+	var out []aplos.Account
+	for _, a := range accts {
+		out = append(out, aplos.Account{
+			AccountNumber: a.number,
+			Name:          a.name,
+			Category:      a.category,
+			AccountGroup:  groups[a.category],
+			IsEnabled:     true,
+		})
+	}
+	return out
+}
+
+func contactName(c aplos.Contact) string {
+	if c.CompanyName != "" {
+		return c.CompanyName
+	}
+	return c.FirstName + " " + c.LastName
+}
+
+func toDate(t time.Time) aplos.Date {
+	y, m, d := t.Date()
+	return aplos.Date{Year: y, Month: m, Day: d}
+}
+
+func lowerASCII(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if 'A' <= c && c <= 'Z' {
+			b[i] = c + 'a' - 'A'
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,114 @@
+package aplostest_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/Silicon-Ally/aplos"
+	"github.com/Silicon-Ally/aplos/aplostest"
+)
+
+func TestFixtures(t *testing.T) {
+	tests := []struct {
+		name  string
+		load  func() *aplostest.Dataset
+		funds int
+	}{
+		{"SmallNonprofit", aplostest.SmallNonprofit, 3},
+		{"LargeChurch", aplostest.LargeChurch, 4},
+		{"FiscalSponsor", aplostest.FiscalSponsor, 10},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := test.load()
+			if !reflect.DeepEqual(d, test.load()) {
+				t.Error("dataset isn't deterministic")
+			}
+			if len(d.Funds) != test.funds {
+				t.Errorf("got %d funds, want %d", len(d.Funds), test.funds)
+			}
+			if len(d.Transactions) == 0 || len(d.Contributions) == 0 {
+				t.Errorf("got %d transactions and %d contributions, want some of each", len(d.Transactions), len(d.Contributions))
+			}
+			for _, txn := range d.Transactions {
+				var sum aplos.Amount
+				for _, l := range txn.Lines {
+					sum += l.Amount
+				}
+				if sum != 0 || len(txn.Lines) < 2 {
+					t.Errorf("transaction %d has %d lines summing to %s, want a balanced entry", txn.ID, len(txn.Lines), sum)
+				}
+			}
+		})
+	}
+}
+
+func TestServerLoad(t *testing.T) {
+	srv := aplostest.NewServer()
+	defer srv.Close()
+	srv.PageSize = 10
+	d := aplostest.SmallNonprofit()
+	srv.Load(d)
+
+	c, err := srv.Client()
+	if err != nil {
+		t.Fatalf("Client: %v", err)
+	}
+	ctx := context.Background()
+
+	funds, err := c.Funds(ctx)
+	if err != nil {
+		t.Fatalf("Funds: %v", err)
+	}
+	if !reflect.DeepEqual(funds, d.Funds) {
+		t.Errorf("Funds() = %+v, want %+v", funds, d.Funds)
+	}
+
+	txns, err := c.Transactions(ctx, aplos.WithRangeStart(2023, 3, 1), aplos.WithRangeEnd(2023, 3, 31))
+	if err != nil {
+		t.Fatalf("Transactions: %v", err)
+	}
+	var want []int
+	for _, txn := range d.Transactions {
+		if txn.Date.Month == 3 {
+			want = append(want, txn.ID)
+		}
+	}
+	var got []int
+	for _, txn := range txns {
+		got = append(got, txn.ID)
+		if txn.Lines != nil {
+			t.Errorf("listed transaction %d has lines, want them stripped", txn.ID)
+		}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("March transaction IDs = %v, want %v", got, want)
+	}
+
+	all, err := c.Contributions(ctx)
+	if err != nil {
+		t.Fatalf("Contributions: %v", err)
+	}
+	if len(all) != len(d.Contributions) {
+		t.Errorf("Contributions() returned %d contributions, want %d across pages", len(all), len(d.Contributions))
+	}
+
+	txn, err := c.Transaction(ctx, d.Transactions[0].ID)
+	if err != nil {
+		t.Fatalf("Transaction: %v", err)
+	}
+	// Times come back in a location from parsing, so compare them separately.
+	want0 := d.Transactions[0]
+	if !txn.Created.Equal(want0.Created.Time) {
+		t.Errorf("Transaction().Created = %v, want %v", txn.Created, want0.Created)
+	}
+	txn.Created, want0.Created = aplos.Time{}, aplos.Time{}
+	if !reflect.DeepEqual(*txn, want0) {
+		t.Errorf("Transaction() = %+v, want %+v", txn, want0)
+	}
+
+	if _, err := c.Transaction(ctx, 1e6); err == nil {
+		t.Error("Transaction() for a missing ID returned no error, want a 404")
+	}
+}
@@ -0,0 +1,197 @@
+package aplostest
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Silicon-Ally/aplos"
+)
+
+// GeneratorConfig configures Generate. Zero fields take the documented
+// defaults.
+type GeneratorConfig struct {
+	// Seed seeds the random number generator; the same config always generates
+	// the same dataset.
+	Seed uint64
+
+	// Start is the first day of the generated ledger, 2023-01-01 by default,
+	// and Months is how many months it covers, 12 by default.
+	Start  aplos.Date
+	Months int
+
+	// Funds is the number of funds, 3 by default. The first is the general fund,
+	// which receives most giving and spending.
+	Funds int
+	// Donors is the number of donor contacts, 50 by default.
+	Donors int
+	// GiftsPerDonor is the average number of gifts each donor makes per year,
+	// 12 by default. MinGift and MaxGift bound the amount of each gift, 25 and
+	// 500 by default.
+	GiftsPerDonor    float64
+	MinGift, MaxGift float64
+	// Seasonality scales giving in each month, indexed by time.Month-1, e.g. to
+	// model year-end giving. By default, giving is heavier in November and
+	// December and lighter over the summer, see DefaultSeasonality.
+	Seasonality *[12]float64
+
+	// ExpensesPerMonth is the number of expense transactions per month, 10 by
+	// default. Spending roughly matches the expected giving.
+	ExpensesPerMonth int
+	// ExpenseMix weights how spending is split between expense accounts, by
+	// account number. By default, it's split evenly between the expense
+	// accounts in the chart of accounts.
+	ExpenseMix map[int]float64
+}
+
+// DefaultSeasonality is the default monthly giving pattern for Generate.
+var DefaultSeasonality = [12]float64{0.9, 0.85, 0.95, 1, 0.95, 0.85, 0.75, 0.75, 0.9, 1, 1.3, 1.8}
+
+// Generate returns a random but deterministic dataset for load testing, with
+// the shared chart of accounts, donors giving throughout each month with
+// deposits on the days gifts are received, and expenses paid out of checking.
+// Unlike the fixtures, its size and shape are configurable.
+func Generate(cfg GeneratorConfig) (*Dataset, error) {
+	if cfg.Start == (aplos.Date{}) {
+		cfg.Start = aplos.Date{Year: 2023, Month: time.January, Day: 1}
+	}
+	cfg.Months = orDefault(cfg.Months, 12)
+	cfg.Funds = orDefault(cfg.Funds, 3)
+	cfg.Donors = orDefault(cfg.Donors, 50)
+	cfg.GiftsPerDonor = orDefault(cfg.GiftsPerDonor, 12)
+	cfg.MinGift = orDefault(cfg.MinGift, 25)
+	cfg.MaxGift = orDefault(cfg.MaxGift, 500)
+	cfg.ExpensesPerMonth = orDefault(cfg.ExpensesPerMonth, 10)
+	if cfg.Seasonality == nil {
+		cfg.Seasonality = &DefaultSeasonality
+	}
+	if cfg.MaxGift < cfg.MinGift {
+		return nil, fmt.Errorf("max gift %g is less than min gift %g", cfg.MaxGift, cfg.MinGift)
+	}
+
+	funds := []string{"General"}
+	for i := 2; i <= cfg.Funds; i++ {
+		funds = append(funds, fmt.Sprintf("Fund %d", i))
+	}
+	b := newFixtureBuilder(cfg.Seed, funds)
+
+	mix, err := expenseMix(b.d.Accounts, cfg.ExpenseMix)
+	if err != nil {
+		return nil, err
+	}
+	donors := b.contacts(cfg.Donors, 4)
+
+	start := time.Date(cfg.Start.Year, cfg.Start.Month, cfg.Start.Day, 0, 0, 0, 0, time.UTC)
+	avgGift := (cfg.MinGift + cfg.MaxGift) / 2
+	for m := 0; m < cfg.Months; m++ {
+		monthStart := start.AddDate(0, m, 0)
+		days := monthStart.AddDate(0, 1, 0).Sub(monthStart).Hours() / 24
+		season := cfg.Seasonality[monthStart.Month()-1]
+
+		// Gifts and expenses for each day of the month.
+		gifts := make(map[int][]aplos.Contact)
+		for _, donor := range donors {
+			n := b.count(cfg.GiftsPerDonor / 12 * season)
+			for i := 0; i < n; i++ {
+				day := b.rng.IntN(int(days))
+				gifts[day] = append(gifts[day], donor)
+			}
+		}
+		budget := float64(cfg.Donors) * cfg.GiftsPerDonor / 12 * avgGift
+		expenses := make(map[int]int)
+		for i := 0; i < cfg.ExpensesPerMonth; i++ {
+			expenses[b.rng.IntN(int(days))]++
+		}
+
+		for day := 0; day < int(days); day++ {
+			t := monthStart.AddDate(0, 0, day)
+			for _, donor := range gifts[day] {
+				amt := cfg.MinGift + b.rng.Float64()*(cfg.MaxGift-cfg.MinGift)
+				b.gift(t, donor, math.Round(amt*100)/100)
+			}
+			b.deposit(t)
+			for i := 0; i < expenses[day]; i++ {
+				acct := mix.pick(b.rng.Float64())
+				fund := 0
+				if b.rng.IntN(3) == 0 {
+					fund = b.rng.IntN(len(b.d.Funds))
+				}
+				amt := budget / float64(cfg.ExpensesPerMonth) * (0.5 + b.rng.Float64())
+				payee := &b.vendors[b.rng.IntN(len(b.vendors))]
+				b.expense(t, accountName(b.d.Accounts, acct), payee, acct, fund, math.Round(amt*100)/100)
+			}
+		}
+	}
+	return b.d, nil
+}
+
+// count returns a random whole number with the given mean.
+func (b *fixtureBuilder) count(mean float64) int {
+	n := int(mean)
+	if b.rng.Float64() < mean-float64(n) {
+		n++
+	}
+	return n
+}
+
+type weightedAccounts struct {
+	accounts []int
+	// cumulative[i] is the total weight of accounts[:i+1], normalized to 1.
+	cumulative []float64
+}
+
+func (w weightedAccounts) pick(r float64) int {
+	i := sort.SearchFloat64s(w.cumulative, r)
+	return w.accounts[min(i, len(w.accounts)-1)]
+}
+
+func expenseMix(accts []aplos.Account, weights map[int]float64) (weightedAccounts, error) {
+	if len(weights) == 0 {
+		weights = make(map[int]float64)
+		for _, a := range accts {
+			if a.Category == "expense" {
+				weights[a.AccountNumber] = 1
+			}
+		}
+	}
+	var w weightedAccounts
+	var total float64
+	for n, weight := range weights {
+		if accountName(accts, n) == "" {
+			return w, fmt.Errorf("expense mix account %d isn't in the chart of accounts", n)
+		}
+		if weight < 0 {
+			return w, fmt.Errorf("expense mix weight for account %d is negative", n)
+		}
+		w.accounts = append(w.accounts, n)
+		total += weight
+	}
+	if total == 0 {
+		return w, fmt.Errorf("expense mix has no positive weights")
+	}
+	sort.Ints(w.accounts)
+	var sum float64
+	for _, n := range w.accounts {
+		sum += weights[n]
+		w.cumulative = append(w.cumulative, sum/total)
+	}
+	return w, nil
+}
+
+func accountName(accts []aplos.Account, n int) string {
+	for _, a := range accts {
+		if a.AccountNumber == n {
+			return a.Name
+		}
+	}
+	return ""
+}
+
+func orDefault[T comparable](v, def T) T {
+	var zero T
+	if v == zero {
+		return def
+	}
+	return v
+}
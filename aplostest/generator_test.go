@@ -0,0 +1,89 @@
+package aplostest_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Silicon-Ally/aplos"
+	"github.com/Silicon-Ally/aplos/aplostest"
+)
+
+func TestGenerate(t *testing.T) {
+	cfg := aplostest.GeneratorConfig{Seed: 42, Donors: 200, Funds: 5}
+	d, err := aplostest.Generate(cfg)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	again, _ := aplostest.Generate(cfg)
+	if !reflect.DeepEqual(d, again) {
+		t.Error("Generate isn't deterministic for the same config")
+	}
+	other, _ := aplostest.Generate(aplostest.GeneratorConfig{Seed: 43, Donors: 200, Funds: 5})
+	if reflect.DeepEqual(d, other) {
+		t.Error("Generate returned the same dataset for different seeds")
+	}
+
+	if len(d.Funds) != 5 {
+		t.Errorf("got %d funds, want 5", len(d.Funds))
+	}
+	// 200 donors giving about monthly, scaled by seasonality.
+	if n := len(d.Contributions); n < 2000 || n > 3000 {
+		t.Errorf("got %d contributions, want about 2400", n)
+	}
+
+	byMonth := make(map[time.Month]aplos.Amount)
+	for _, c := range d.Contributions {
+		if c.Date.Year != 2023 {
+			t.Fatalf("contribution %d dated %v, want within 2023", c.ID, c.Date)
+		}
+		if c.Amount < 25_00 || c.Amount > 500_00 {
+			t.Errorf("contribution %d for %v, want between 25 and 500", c.ID, c.Amount)
+		}
+		byMonth[c.Date.Month] += c.Amount
+	}
+	if byMonth[time.December].Float64() < 1.5*byMonth[time.July].Float64() {
+		t.Errorf("December giving %s isn't well above July giving %s", byMonth[time.December], byMonth[time.July])
+	}
+
+	for _, txn := range d.Transactions {
+		var sum aplos.Amount
+		for _, l := range txn.Lines {
+			sum += l.Amount
+		}
+		if sum != 0 {
+			t.Errorf("transaction %d is off balance by %s", txn.ID, sum)
+		}
+	}
+}
+
+func TestGenerateExpenseMix(t *testing.T) {
+	d, err := aplostest.Generate(aplostest.GeneratorConfig{
+		Seed:       1,
+		Months:     3,
+		ExpenseMix: map[int]float64{aplostest.AccountSalaries: 3, aplostest.AccountRent: 1},
+	})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	counts := make(map[int]int)
+	for _, txn := range d.Transactions {
+		for _, l := range txn.Lines {
+			if l.Amount > 0 && l.Account.AccountNumber >= 5000 {
+				counts[l.Account.AccountNumber]++
+			}
+		}
+	}
+	if len(counts) != 2 || counts[aplostest.AccountSalaries] <= counts[aplostest.AccountRent] {
+		t.Errorf("expense counts by account = %v, want mostly salaries and some rent", counts)
+	}
+
+	for _, mix := range []map[int]float64{{9999: 1}, {aplostest.AccountRent: 0}, {aplostest.AccountRent: -1}} {
+		if _, err := aplostest.Generate(aplostest.GeneratorConfig{ExpenseMix: mix}); err == nil {
+			t.Errorf("Generate with expense mix %v returned no error, want one", mix)
+		}
+	}
+	if _, err := aplostest.Generate(aplostest.GeneratorConfig{MinGift: 100, MaxGift: 10}); err == nil {
+		t.Error("Generate with MaxGift < MinGift returned no error, want one")
+	}
+}
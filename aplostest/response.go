@@ -0,0 +1,34 @@
+package aplostest
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// WriteData writes a successful response with the given data, wrapped in the
+// API's response envelope.
+func WriteData(w http.ResponseWriter, data interface{}) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"version": "0.0.1",
+		"status":  http.StatusOK,
+		"data":    data,
+	})
+}
+
+// WriteError writes an error response with the given status code and message.
+func WriteError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]interface{}{
+		"version": "0.0.1",
+		"status":  status,
+		"exception": map[string]interface{}{
+			"code":    status,
+			"message": msg,
+		},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,140 @@
+package aplos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BackoffStore persists how long a client should hold off on making requests
+// after being rate limited, so that the backoff survives process restarts.
+type BackoffStore interface {
+	// LoadBackoff returns the time until which requests should be held off, or
+	// the zero time if there's no backoff.
+	LoadBackoff() (time.Time, error)
+	// SaveBackoff records the time until which requests should be held off.
+	SaveBackoff(until time.Time) error
+}
+
+// FileBackoffStore is a BackoffStore that keeps the backoff in a file, as an
+// RFC 3339 timestamp. Orchestration can call LoadBackoff to find out how long to
+// delay the next run of a job.
+type FileBackoffStore struct {
+	Path string
+}
+
+func (f FileBackoffStore) LoadBackoff() (time.Time, error) {
+	dat, err := os.ReadFile(f.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read backoff file: %w", err)
+	}
+	s := strings.TrimSpace(string(dat))
+	if s == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse backoff file: %w", err)
+	}
+	return t, nil
+}
+
+func (f FileBackoffStore) SaveBackoff(until time.Time) error {
+	if err := os.WriteFile(f.Path, []byte(until.UTC().Format(time.RFC3339)+"\n"), 0o644); err != nil {
+		return fmt.Errorf("failed to write backoff file: %w", err)
+	}
+	return nil
+}
+
+// backoff holds off requests after the API rate limits us. A nil *backoff
+// doesn't hold off anything.
+type backoff struct {
+	store BackoffStore
+	now   func() time.Time
+
+	mu    sync.Mutex
+	until time.Time
+}
+
+func newBackoff(s BackoffStore) (*backoff, error) {
+	if s == nil {
+		return nil, nil
+	}
+	until, err := s.LoadBackoff()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load backoff state: %w", err)
+	}
+	return &backoff{store: s, now: time.Now, until: until}, nil
+}
+
+func (b *backoff) Until() time.Time {
+	if b == nil {
+		return time.Time{}
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.until
+}
+
+// Wait blocks until the backoff has passed, or the context is done.
+func (b *backoff) Wait(ctx context.Context) error {
+	if b == nil {
+		return nil
+	}
+	d := b.Until().Sub(b.now())
+	if d <= 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Observe extends the backoff if err is the API telling us to slow down, i.e. a
+// 429, or a retryable error with a Retry-After header. The returned error is
+// from saving the new backoff, if any.
+func (b *backoff) Observe(err error) error {
+	if b == nil {
+		return nil
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return nil
+	}
+	if apiErr.StatusCode != http.StatusTooManyRequests && !apiErr.hasRetryAfter {
+		return nil
+	}
+	d, ok := apiErr.RetryAfter()
+	if !ok {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	until := b.now().Add(d)
+	if !until.After(b.until) {
+		return nil
+	}
+	b.until = until
+	return b.store.SaveBackoff(until)
+}
+
+// BackoffUntil returns the time until which the client is holding off on
+// requests because the API rate limited it, or the zero time if it isn't. It's
+// always zero unless the client was created with WithBackoffStore.
+func (c *Client) BackoffUntil() time.Time {
+	return c.backoff.Until()
+}
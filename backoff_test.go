@@ -0,0 +1,80 @@
+package aplos
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileBackoffStore(t *testing.T) {
+	s := FileBackoffStore{Path: filepath.Join(t.TempDir(), "backoff")}
+
+	got, err := s.LoadBackoff()
+	if err != nil || !got.IsZero() {
+		t.Errorf("LoadBackoff() with no file = %v, %v, want the zero time", got, err)
+	}
+
+	until := time.Date(2023, time.May, 1, 12, 30, 0, 0, time.UTC)
+	if err := s.SaveBackoff(until); err != nil {
+		t.Fatalf("SaveBackoff: %v", err)
+	}
+	if got, err := s.LoadBackoff(); err != nil || !got.Equal(until) {
+		t.Errorf("LoadBackoff() = %v, %v, want %v", got, err, until)
+	}
+}
+
+func TestBackoffPersistsAcrossClients(t *testing.T) {
+	var requests int
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	now := time.Date(2023, time.May, 1, 12, 0, 0, 0, time.UTC)
+	store := FileBackoffStore{Path: filepath.Join(t.TempDir(), "backoff")}
+
+	b, err := newBackoff(store)
+	if err != nil {
+		t.Fatalf("newBackoff: %v", err)
+	}
+	b.now = func() time.Time { return now }
+	c.backoff = b
+
+	if _, err := c.Fund(context.Background(), 1); err == nil {
+		t.Fatal("Fund() returned no error, want a 429")
+	}
+	want := now.Add(2 * time.Minute)
+	if got := c.BackoffUntil(); !got.Equal(want) {
+		t.Errorf("BackoffUntil() = %v, want %v", got, want)
+	}
+	if got, err := store.LoadBackoff(); err != nil || !got.Equal(want) {
+		t.Errorf("stored backoff = %v, %v, want %v", got, err, want)
+	}
+
+	// A client started after a restart picks up the backoff, and doesn't make
+	// any requests until it's over.
+	b, err = newBackoff(store)
+	if err != nil {
+		t.Fatalf("newBackoff: %v", err)
+	}
+	b.now = func() time.Time { return now.Add(time.Minute) }
+	c.backoff = b
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := c.Fund(ctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Fund() during backoff = %v, want context.DeadlineExceeded", err)
+	}
+	if requests != 1 {
+		t.Errorf("server got %d requests, want 1", requests)
+	}
+
+	// Once the backoff has passed, requests go through again.
+	b.now = func() time.Time { return now.Add(3 * time.Minute) }
+	c.Fund(context.Background(), 1)
+	if requests != 2 {
+		t.Errorf("server got %d requests after the backoff, want 2", requests)
+	}
+}
@@ -0,0 +1,67 @@
+package aplos_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Silicon-Ally/aplos/aplostest"
+)
+
+// benchDataset generates a dataset for benchmarks with the given number of
+// donors over two years, about 24 contributions per donor. See BENCHMARKS.md
+// for baselines.
+func benchDataset(b *testing.B, donors int) *aplostest.Dataset {
+	b.Helper()
+	d, err := aplostest.Generate(aplostest.GeneratorConfig{Seed: 1, Months: 24, Donors: donors, ExpensesPerMonth: 100})
+	if err != nil {
+		b.Fatalf("Generate: %v", err)
+	}
+	return d
+}
+
+// BenchmarkListContributions measures listing every contribution from the
+// fake server, including pagination and decoding.
+func BenchmarkListContributions(b *testing.B) {
+	for _, donors := range []int{100, 500} {
+		b.Run(fmt.Sprintf("donors=%d", donors), func(b *testing.B) {
+			srv := aplostest.NewServer()
+			defer srv.Close()
+			srv.Load(benchDataset(b, donors))
+			c, err := srv.Client()
+			if err != nil {
+				b.Fatalf("Client: %v", err)
+			}
+			ctx := context.Background()
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := c.Contributions(ctx); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkListTransactions measures listing every transaction from the fake
+// server, including pagination and decoding.
+func BenchmarkListTransactions(b *testing.B) {
+	srv := aplostest.NewServer()
+	defer srv.Close()
+	srv.Load(benchDataset(b, 2000))
+	c, err := srv.Client()
+	if err != nil {
+		b.Fatalf("Client: %v", err)
+	}
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := c.Transactions(ctx); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
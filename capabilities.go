@@ -0,0 +1,91 @@
+package aplos
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Operations are written as "<resource>:<access>", where resource is the first
+// segment of the API path, like "transactions" or "contacts", and access is
+// "read" or "write". Either part can be "*" to match anything, e.g.
+// "*:read" allows reading any resource.
+const (
+	accessRead  = "read"
+	accessWrite = "write"
+)
+
+// OperationNotAllowedError is returned when a client created with
+// WithAllowedOperations attempts an operation outside of its allowlist. No
+// request is sent to Aplos in this case.
+type OperationNotAllowedError struct {
+	// Operation is the disallowed operation, like "contributions:write".
+	Operation string
+}
+
+func (e *OperationNotAllowedError) Error() string {
+	return fmt.Sprintf("operation %q is not allowed for this client", e.Operation)
+}
+
+// capabilities is a set of allowed operations. A nil capabilities allows
+// everything.
+type capabilities map[string]bool
+
+func parseCapabilities(ops []string) (capabilities, error) {
+	caps := make(capabilities)
+	for _, op := range ops {
+		resource, access, ok := strings.Cut(op, ":")
+		if !ok || resource == "" {
+			return nil, fmt.Errorf("malformed operation %q, should be formatted like 'transactions:read'", op)
+		}
+		switch access {
+		case accessRead, accessWrite, "*":
+		default:
+			return nil, fmt.Errorf("malformed operation %q, access must be 'read', 'write', or '*'", op)
+		}
+		caps[resource+":"+access] = true
+	}
+	return caps, nil
+}
+
+func (c capabilities) allows(resource, access string) bool {
+	if c == nil {
+		return true
+	}
+	return c[resource+":"+access] || c[resource+":*"] || c["*:"+access] || c["*:*"]
+}
+
+// checkAllowed returns an *OperationNotAllowedError if a request with the given
+// method to the given URL is outside of the client's allowed operations.
+func (c *Client) checkAllowed(method, u string) error {
+	if c.capabilities == nil {
+		return nil
+	}
+
+	resource := resourceFor(c.baseURL, u)
+	access := accessRead
+	if isUnsafeMethod(method) {
+		access = accessWrite
+	}
+	if !c.capabilities.allows(resource, access) {
+		return &OperationNotAllowedError{Operation: resource + ":" + access}
+	}
+	return nil
+}
+
+// resourceFor returns the first path segment of u after the base URL, e.g.
+// "transactions" for https://www.aplos.com/hermes/api/v1/transactions/123.
+func resourceFor(baseURL, u string) string {
+	bu, err := url.Parse(baseURL)
+	if err != nil {
+		return ""
+	}
+	pu, err := url.Parse(u)
+	if err != nil {
+		return ""
+	}
+	p := strings.TrimPrefix(pu.Path, strings.TrimSuffix(bu.Path, "/"))
+	p = strings.TrimPrefix(p, "/")
+	resource, _, _ := strings.Cut(p, "/")
+	return resource
+}
@@ -0,0 +1,92 @@
+package aplos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestCapabilities(t *testing.T) {
+	tests := []struct {
+		desc     string
+		ops      []string
+		resource string
+		access   string
+		want     bool
+	}{
+		{desc: "exact match", ops: []string{"transactions:read"}, resource: "transactions", access: "read", want: true},
+		{desc: "read doesn't allow write", ops: []string{"transactions:read"}, resource: "transactions", access: "write", want: false},
+		{desc: "other resource", ops: []string{"transactions:read"}, resource: "contacts", access: "read", want: false},
+		{desc: "any access", ops: []string{"contacts:*"}, resource: "contacts", access: "write", want: true},
+		{desc: "any resource", ops: []string{"*:read"}, resource: "accounts", access: "read", want: true},
+		{desc: "any resource, wrong access", ops: []string{"*:read"}, resource: "accounts", access: "write", want: false},
+		{desc: "everything", ops: []string{"*:*"}, resource: "funds", access: "write", want: true},
+		{desc: "nothing", ops: []string{}, resource: "funds", access: "read", want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			caps, err := parseCapabilities(test.ops)
+			if err != nil {
+				t.Fatalf("parseCapabilities: %v", err)
+			}
+			if got := caps.allows(test.resource, test.access); got != test.want {
+				t.Errorf("allows(%q, %q) = %t, want %t", test.resource, test.access, got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseCapabilitiesInvalid(t *testing.T) {
+	for _, op := range []string{"transactions", ":read", "transactions:delete"} {
+		if _, err := parseCapabilities([]string{op}); err == nil {
+			t.Errorf("parseCapabilities(%q) returned no error, want one", op)
+		}
+	}
+}
+
+func TestClientNotAllowed(t *testing.T) {
+	var requests int
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"status": 200, "data": {"accounts": [], "transactions": []}}`)
+	}))
+	caps, err := parseCapabilities([]string{"accounts:read"})
+	if err != nil {
+		t.Fatalf("parseCapabilities: %v", err)
+	}
+	c.capabilities = caps
+
+	if _, err := c.Accounts(context.Background()); err != nil {
+		t.Errorf("Accounts: %v", err)
+	}
+
+	_, err = c.Transactions(context.Background())
+	var notAllowed *OperationNotAllowedError
+	if !errors.As(err, &notAllowed) {
+		t.Fatalf("Transactions() returned %v, want an *OperationNotAllowedError", err)
+	}
+	if notAllowed.Operation != "transactions:read" {
+		t.Errorf("Operation = %q, want %q", notAllowed.Operation, "transactions:read")
+	}
+	if requests != 1 {
+		t.Errorf("made %d requests, want 1", requests)
+	}
+}
+
+func TestResourceFor(t *testing.T) {
+	tests := []struct {
+		base, u, want string
+	}{
+		{base: defaultBaseURL, u: defaultBaseURL + "/transactions/123", want: "transactions"},
+		{base: defaultBaseURL, u: defaultBaseURL + "/accounts?f_name=x", want: "accounts"},
+		{base: "http://127.0.0.1:1234", u: "http://127.0.0.1:1234/contacts", want: "contacts"},
+	}
+	for _, test := range tests {
+		if got := resourceFor(test.base, test.u); got != test.want {
+			t.Errorf("resourceFor(%q, %q) = %q, want %q", test.base, test.u, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,600 @@
+// Command aplos-openapi generates an OpenAPI 3 description of the Aplos API
+// endpoints wrapped by the aplos package, with schemas derived from the
+// package's Go types. It allows non-Go consumers to generate clients that
+// agree with this one.
+//
+// Usage:
+//
+//	go run ./cmd/aplos-openapi --out=openapi.json
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/Silicon-Ally/aplos"
+)
+
+func main() {
+	if err := run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(args []string) error {
+	if len(args) == 0 {
+		return errors.New("args cannot be empty")
+	}
+
+	fs := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	var (
+		out = fs.String("out", "", "Optional. The file to write the spec to, defaults to stdout.")
+	)
+	if err := fs.Parse(args[1:]); err != nil {
+		return fmt.Errorf("failed to parse flags: %v", err)
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(buildSpec()); err != nil {
+		return fmt.Errorf("failed to write spec: %w", err)
+	}
+	return nil
+}
+
+type schema map[string]interface{}
+
+type endpoint struct {
+	method      string
+	path        string
+	summary     string
+	params      []param
+	dataKey     string
+	dataType    reflect.Type
+	list        bool
+	rawDataType schema
+	// bodyType is the type of the JSON request body, if any.
+	bodyType reflect.Type
+}
+
+type param struct {
+	name   string
+	in     string
+	desc   string
+	schema schema
+}
+
+var (
+	strSchema  = schema{"type": "string"}
+	intSchema  = schema{"type": "integer"}
+	numSchema  = schema{"type": "number"}
+	boolSchema = schema{"type": "boolean"}
+	dateSchema = schema{"type": "string", "format": "date"}
+
+	// pageLinksSchema and pageMetaSchema describe the pagination envelope of
+	// list responses. The client follows the next link until it's empty.
+	pageLinksSchema = schema{
+		"type": "object",
+		"properties": schema{
+			"first": schema{"type": "string", "description": "URL of the first page"},
+			"prev":  schema{"type": "string", "description": "URL of the previous page, empty on the first page"},
+			"next":  schema{"type": "string", "description": "URL of the next page, empty on the last page"},
+			"last":  schema{"type": "string", "description": "URL of the last page"},
+		},
+	}
+	pageMetaSchema = schema{
+		"type": "object",
+		"properties": schema{
+			"record_count": schema{"type": "integer", "description": "Number of records matching the request across all pages"},
+			"page_count":   schema{"type": "integer", "description": "Number of pages"},
+			"page_num":     schema{"type": "integer", "description": "Number of this page, starting from 1"},
+		},
+	}
+)
+
+// endpoints lists the API surface wrapped by the aplos package. It should be
+// kept in sync with the client methods.
+var endpoints = []endpoint{
+	{
+		method:  "get",
+		path:    "/auth/{client_id}",
+		summary: "Retrieve an access token encrypted with the API key's public key",
+		params: []param{
+			{name: "client_id", in: "path", desc: "The Aplos Client ID of the API key", schema: strSchema},
+		},
+		rawDataType: schema{
+			"type": "object",
+			"properties": schema{
+				"expires": schema{"type": "string", "format": "date-time"},
+				"token":   schema{"type": "string", "description": "The base64-encoded, RSA encrypted access token"},
+			},
+		},
+	},
+	{
+		method:  "get",
+		path:    "/accounts",
+		summary: "List accounts",
+		params: []param{
+			{name: "f_name", in: "query", desc: "Filter by account name", schema: strSchema},
+			{name: "f_category", in: "query", desc: "Filter by account category, e.g. 'expense'", schema: strSchema},
+			{name: "f_type", in: "query", desc: "Filter by account type", schema: strSchema},
+			{name: "f_enabled", in: "query", desc: "Filter by whether the account is enabled", schema: boolSchema},
+		},
+		dataKey:  "accounts",
+		dataType: reflect.TypeOf(aplos.Account{}),
+		list:     true,
+	},
+	{
+		method:  "get",
+		path:    "/accounts/{account_number}",
+		summary: "Get a single account",
+		params: []param{
+			{name: "account_number", in: "path", desc: "The number of the account", schema: intSchema},
+		},
+		dataKey:  "account",
+		dataType: reflect.TypeOf(aplos.Account{}),
+	},
+	{
+		method:   "get",
+		path:     "/partners/organizations",
+		summary:  "List organizations managed by a partner account",
+		dataKey:  "organizations",
+		dataType: reflect.TypeOf(aplos.Organization{}),
+		list:     true,
+	},
+	{
+		method:   "get",
+		path:     "/accountgroups",
+		summary:  "List account groups",
+		dataKey:  "account_groups",
+		dataType: reflect.TypeOf(aplos.AccountGroup{}),
+		list:     true,
+	},
+	{
+		method:   "post",
+		path:     "/accountgroups",
+		summary:  "Create an account group",
+		bodyType: reflect.TypeOf(AccountGroupRequest{}),
+		dataKey:  "account_group",
+		dataType: reflect.TypeOf(aplos.AccountGroup{}),
+	},
+	{
+		method:   "post",
+		path:     "/accounts",
+		summary:  "Create an account",
+		bodyType: reflect.TypeOf(AccountRequest{}),
+		dataKey:  "account",
+		dataType: reflect.TypeOf(aplos.Account{}),
+	},
+	{
+		method:  "put",
+		path:    "/accounts/{account_number}",
+		summary: "Update an account",
+		params: []param{
+			{name: "account_number", in: "path", desc: "The current number of the account", schema: intSchema},
+		},
+		bodyType: reflect.TypeOf(AccountRequest{}),
+		dataKey:  "account",
+		dataType: reflect.TypeOf(aplos.Account{}),
+	},
+	{
+		method:  "get",
+		path:    "/transactions",
+		summary: "List transactions",
+		params: []param{
+			{name: "f_accountnumber", in: "query", desc: "Filter by account number", schema: intSchema},
+			{name: "f_idnumber", in: "query", desc: "Filter by register reference number, e.g. check number", schema: intSchema},
+			{name: "f_contact", in: "query", desc: "Filter by contact ID", schema: intSchema},
+			{name: "f_memo", in: "query", desc: "Only include transactions whose memo contains this text", schema: strSchema},
+			{name: "f_amountmin", in: "query", desc: "Only include transactions with at least this amount", schema: numSchema},
+			{name: "f_amountmax", in: "query", desc: "Only include transactions with at most this amount", schema: numSchema},
+			{name: "f_rangestart", in: "query", desc: "Only include transactions on or after this date", schema: dateSchema},
+			{name: "f_rangeend", in: "query", desc: "Only include transactions on or before this date", schema: dateSchema},
+		},
+		dataKey:  "transactions",
+		dataType: reflect.TypeOf(aplos.Transaction{}),
+		list:     true,
+	},
+	{
+		method:  "get",
+		path:    "/transactions/{id}",
+		summary: "Get a single transaction, including its lines",
+		params: []param{
+			{name: "id", in: "path", desc: "The ID of the transaction", schema: intSchema},
+		},
+		dataKey:  "transaction",
+		dataType: reflect.TypeOf(aplos.Transaction{}),
+	},
+	{
+		method:   "post",
+		path:     "/transactions",
+		summary:  "Create a journal entry",
+		bodyType: reflect.TypeOf(TransactionRequest{}),
+		dataKey:  "transaction",
+		dataType: reflect.TypeOf(aplos.Transaction{}),
+	},
+	{
+		method:  "put",
+		path:    "/transactions/{id}",
+		summary: "Update a journal entry",
+		params: []param{
+			{name: "id", in: "path", desc: "The ID of the transaction", schema: intSchema},
+		},
+		bodyType: reflect.TypeOf(TransactionRequest{}),
+		dataKey:  "transaction",
+		dataType: reflect.TypeOf(aplos.Transaction{}),
+	},
+	{
+		method:  "delete",
+		path:    "/transactions/{id}",
+		summary: "Delete a transaction",
+		params: []param{
+			{name: "id", in: "path", desc: "The ID of the transaction", schema: intSchema},
+		},
+		rawDataType: schema{"type": "object"},
+	},
+	{
+		method:  "get",
+		path:    "/contacts",
+		summary: "List contacts",
+		params: []param{
+			{name: "f_name", in: "query", desc: "Filter by contact name", schema: strSchema},
+			{name: "f_email", in: "query", desc: "Filter by email address", schema: strSchema},
+			{name: "f_type", in: "query", desc: "Filter by contact type, 'individual' or 'company'", schema: strSchema},
+		},
+		dataKey:  "contacts",
+		dataType: reflect.TypeOf(aplos.Contact{}),
+		list:     true,
+	},
+	{
+		method:  "get",
+		path:    "/contacts/{id}",
+		summary: "Get a single contact",
+		params: []param{
+			{name: "id", in: "path", desc: "The ID of the contact", schema: intSchema},
+		},
+		dataKey:  "contact",
+		dataType: reflect.TypeOf(aplos.Contact{}),
+	},
+	{
+		method:  "get",
+		path:    "/funds",
+		summary: "List funds",
+		params: []param{
+			{name: "f_name", in: "query", desc: "Filter by fund name", schema: strSchema},
+			{name: "f_enabled", in: "query", desc: "Filter by whether the fund is enabled", schema: boolSchema},
+		},
+		dataKey:  "funds",
+		dataType: reflect.TypeOf(aplos.Fund{}),
+		list:     true,
+	},
+	{
+		method:  "get",
+		path:    "/funds/{id}",
+		summary: "Get a single fund",
+		params: []param{
+			{name: "id", in: "path", desc: "The ID of the fund", schema: intSchema},
+		},
+		dataKey:  "fund",
+		dataType: reflect.TypeOf(aplos.Fund{}),
+	},
+	{
+		method:  "get",
+		path:    "/reports/fundbalances",
+		summary: "Get starting balance, income, expense, and ending balance per fund for a period",
+		params: []param{
+			{name: "f_rangestart", in: "query", desc: "The first day of the period", schema: dateSchema},
+			{name: "f_rangeend", in: "query", desc: "The last day of the period", schema: dateSchema},
+		},
+		dataKey:  "fund_balances",
+		dataType: reflect.TypeOf(aplos.FundBalance{}),
+		list:     true,
+	},
+	{
+		method:  "get",
+		path:    "/contributions",
+		summary: "List contributions",
+		params: []param{
+			{name: "f_rangestart", in: "query", desc: "Only include contributions on or after this date", schema: dateSchema},
+			{name: "f_rangeend", in: "query", desc: "Only include contributions on or before this date", schema: dateSchema},
+			{name: "f_purpose", in: "query", desc: "Filter by purpose ID", schema: intSchema},
+			{name: "f_contact", in: "query", desc: "Filter by contact ID", schema: intSchema},
+		},
+		dataKey:  "contributions",
+		dataType: reflect.TypeOf(aplos.Contribution{}),
+		list:     true,
+	},
+	{
+		method:  "get",
+		path:    "/contributions/{id}",
+		summary: "Get a single contribution",
+		params: []param{
+			{name: "id", in: "path", desc: "The ID of the contribution", schema: intSchema},
+		},
+		dataKey:  "contribution",
+		dataType: reflect.TypeOf(aplos.Contribution{}),
+	},
+	{
+		method:  "get",
+		path:    "/purposes",
+		summary: "List contribution purposes",
+		params: []param{
+			{name: "f_name", in: "query", desc: "Filter by purpose name", schema: strSchema},
+		},
+		dataKey:  "purposes",
+		dataType: reflect.TypeOf(aplos.Purpose{}),
+		list:     true,
+	},
+	{
+		method:  "get",
+		path:    "/purposes/{id}",
+		summary: "Get a single contribution purpose",
+		params: []param{
+			{name: "id", in: "path", desc: "The ID of the purpose", schema: intSchema},
+		},
+		dataKey:  "purpose",
+		dataType: reflect.TypeOf(aplos.Purpose{}),
+	},
+	{
+		method:   "post",
+		path:     "/contributions",
+		summary:  "Record a contribution",
+		bodyType: reflect.TypeOf(ContributionRequest{}),
+		dataKey:  "contribution",
+		dataType: reflect.TypeOf(aplos.Contribution{}),
+	},
+	{
+		method:   "post",
+		path:     "/contacts",
+		summary:  "Create a contact",
+		bodyType: reflect.TypeOf(aplos.Contact{}),
+		dataKey:  "contact",
+		dataType: reflect.TypeOf(aplos.Contact{}),
+	},
+	{
+		method:  "put",
+		path:    "/contacts/{id}",
+		summary: "Update a contact",
+		params: []param{
+			{name: "id", in: "path", desc: "The ID of the contact", schema: intSchema},
+		},
+		bodyType: reflect.TypeOf(aplos.Contact{}),
+		dataKey:  "contact",
+		dataType: reflect.TypeOf(aplos.Contact{}),
+	},
+}
+
+// AccountGroupRequest mirrors the unexported request body sent by
+// aplos.Client.CreateAccountGroup.
+type AccountGroupRequest struct {
+	Name string `json:"name"`
+	Seq  int    `json:"seq"`
+}
+
+// AccountRequest mirrors the unexported request body sent by
+// aplos.Client.CreateAccount and UpdateAccount.
+type AccountRequest struct {
+	AccountNumber  int    `json:"account_number"`
+	Name           string `json:"name"`
+	Category       string `json:"category"`
+	Type           string `json:"type"`
+	AccountGroupID int    `json:"account_group_id"`
+	IsEnabled      bool   `json:"is_enabled"`
+}
+
+// TransactionRequest mirrors the unexported request body sent by
+// aplos.Client.CreateTransaction and UpdateTransaction, which refers to related records by ID.
+type TransactionRequest struct {
+	Date      aplos.Date               `json:"date"`
+	Memo      string                   `json:"memo"`
+	IDNumber  int                      `json:"id_number"`
+	ContactID int                      `json:"contact_id"`
+	Lines     []TransactionRequestLine `json:"lines"`
+}
+
+type TransactionRequestLine struct {
+	AccountNumber int          `json:"account_number"`
+	FundID        int          `json:"fund_id"`
+	Amount        aplos.Amount `json:"amount"`
+}
+
+// ContributionRequest mirrors the unexported request body sent by
+// aplos.Client.CreateContribution, which refers to related records by ID.
+type ContributionRequest struct {
+	Date          aplos.Date                `json:"date"`
+	Amount        aplos.Amount              `json:"amount"`
+	PaymentMethod string                    `json:"payment_method"`
+	CheckNumber   string                    `json:"check_number"`
+	ContactID     int                       `json:"contact_id"`
+	PurposeID     int                       `json:"purpose_id"`
+	Note          string                    `json:"note"`
+	Funds         []ContributionRequestFund `json:"funds"`
+}
+
+type ContributionRequestFund struct {
+	FundID int          `json:"fund_id"`
+	Amount aplos.Amount `json:"amount"`
+}
+
+func buildSpec() schema {
+	g := &generator{components: schema{
+		"PageLinks": pageLinksSchema,
+		"PageMeta":  pageMetaSchema,
+	}}
+
+	paths := schema{}
+	for _, e := range endpoints {
+		var params []schema
+		for _, p := range e.params {
+			params = append(params, schema{
+				"name":        p.name,
+				"in":          p.in,
+				"description": p.desc,
+				"required":    p.in == "path",
+				"schema":      p.schema,
+			})
+		}
+
+		data := e.rawDataType
+		if data == nil {
+			s := g.schemaFor(e.dataType)
+			if e.list {
+				s = schema{"type": "array", "items": s}
+			}
+			data = schema{
+				"type":       "object",
+				"properties": schema{e.dataKey: s},
+			}
+		}
+
+		props := schema{
+			"version": strSchema,
+			"status":  intSchema,
+			"data":    data,
+		}
+		if e.list {
+			props["links"] = schema{"$ref": "#/components/schemas/PageLinks"}
+			props["meta"] = schema{"$ref": "#/components/schemas/PageMeta"}
+		}
+
+		op := schema{
+			"summary": e.summary,
+			"responses": schema{
+				"200": schema{
+					"description": "Success",
+					"content": schema{
+						"application/json": schema{
+							"schema": schema{
+								"type":       "object",
+								"properties": props,
+							},
+						},
+					},
+				},
+			},
+		}
+		if len(params) > 0 {
+			op["parameters"] = params
+		}
+		if e.bodyType != nil {
+			op["requestBody"] = schema{
+				"required": true,
+				"content": schema{
+					"application/json": schema{"schema": g.schemaFor(e.bodyType)},
+				},
+			}
+		}
+		if !strings.HasPrefix(e.path, "/auth/") {
+			op["security"] = []schema{{"bearerAuth": []string{}}}
+		}
+
+		item, ok := paths[e.path].(schema)
+		if !ok {
+			item = schema{}
+			paths[e.path] = item
+		}
+		item[e.method] = op
+	}
+
+	return schema{
+		"openapi": "3.0.3",
+		"info": schema{
+			"title":   "Aplos API",
+			"version": "v1",
+		},
+		"servers": []schema{{"url": "https://www.aplos.com/hermes/api/v1"}},
+		"paths":   paths,
+		"components": schema{
+			"schemas": g.components,
+			"securitySchemes": schema{
+				"bearerAuth": schema{"type": "http", "scheme": "bearer"},
+			},
+		},
+	}
+}
+
+type generator struct {
+	components schema
+}
+
+var (
+	dateType   = reflect.TypeOf(aplos.Date{})
+	timeType   = reflect.TypeOf(aplos.Time{})
+	amountType = reflect.TypeOf(aplos.Amount(0))
+)
+
+func (g *generator) schemaFor(t reflect.Type) schema {
+	switch t {
+	case dateType:
+		return schema{"type": "string", "format": "date"}
+	case timeType:
+		return schema{"type": "string", "format": "date-time"}
+	case amountType:
+		// Amounts are stored in cents, but marshal as decimal dollars.
+		return schema{"type": "number"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		s := g.schemaFor(t.Elem())
+		if _, isRef := s["$ref"]; isRef {
+			return schema{"allOf": []schema{s}, "nullable": true}
+		}
+		s["nullable"] = true
+		return s
+	case reflect.Slice:
+		return schema{"type": "array", "items": g.schemaFor(t.Elem())}
+	case reflect.Bool:
+		return schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return schema{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return schema{"type": "number"}
+	case reflect.String:
+		return schema{"type": "string"}
+	case reflect.Struct:
+		ref := schema{"$ref": "#/components/schemas/" + t.Name()}
+		if _, ok := g.components[t.Name()]; ok {
+			return ref
+		}
+		// Register the name before recursing to handle self-referential types.
+		g.components[t.Name()] = schema{}
+		props := schema{}
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			name := strings.ToLower(f.Name)
+			tag, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if tag == "-" {
+				continue
+			} else if tag != "" {
+				name = tag
+			}
+			props[name] = g.schemaFor(f.Type)
+		}
+		g.components[t.Name()] = schema{"type": "object", "properties": props}
+		return ref
+	default:
+		return schema{}
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAmountSchema(t *testing.T) {
+	components := buildSpec()["components"].(schema)["schemas"].(schema)
+	num := schema{"type": "number"}
+	tests := []struct {
+		component string
+		fields    []string
+	}{
+		{"Transaction", []string{"amount"}},
+		{"TransactionLine", []string{"amount"}},
+		{"Contribution", []string{"amount"}},
+		{"FundBalance", []string{"starting_balance", "income", "expense", "ending_balance"}},
+		{"TransactionRequestLine", []string{"amount"}},
+		{"ContributionRequest", []string{"amount"}},
+		{"ContributionRequestFund", []string{"amount"}},
+	}
+	for _, test := range tests {
+		c, ok := components[test.component].(schema)
+		if !ok {
+			t.Errorf("no %s component in spec", test.component)
+			continue
+		}
+		props := c["properties"].(schema)
+		for _, f := range test.fields {
+			if got := props[f]; !reflect.DeepEqual(got, num) {
+				t.Errorf("%s.%s schema = %v, want %v", test.component, f, got, num)
+			}
+		}
+	}
+}
+
+func TestListEnvelope(t *testing.T) {
+	spec := buildSpec()
+	components := spec["components"].(schema)["schemas"].(schema)
+	for _, name := range []string{"PageLinks", "PageMeta"} {
+		if _, ok := components[name]; !ok {
+			t.Errorf("no %s component in spec", name)
+		}
+	}
+
+	props := func(path, method string) schema {
+		op := spec["paths"].(schema)[path].(schema)[method].(schema)
+		resp := op["responses"].(schema)["200"].(schema)["content"].(schema)["application/json"].(schema)
+		return resp["schema"].(schema)["properties"].(schema)
+	}
+	list := props("/transactions", "get")
+	for _, key := range []string{"links", "meta"} {
+		if _, ok := list[key]; !ok {
+			t.Errorf("GET /transactions response has no %q property", key)
+		}
+	}
+	single := props("/transactions/{id}", "get")
+	for _, key := range []string{"links", "meta"} {
+		if _, ok := single[key]; ok {
+			t.Errorf("GET /transactions/{id} response has a %q property, want none", key)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Command aplos-rollback undoes an import, by deleting every transaction that
+// importer.ImportTransactions created in a batch. Credentials are read from
+// the environment, see aplos.NewFromEnv.
+//
+// Usage:
+//
+//	go run ./cmd/aplos-rollback --batch=20230507-9f86d081 --dry_run
+//
+// Run it with --dry_run first to check which transactions would be deleted.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Silicon-Ally/aplos"
+	"github.com/Silicon-Ally/aplos/importer"
+)
+
+func main() {
+	if err := run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(args []string) error {
+	if len(args) == 0 {
+		return errors.New("args cannot be empty")
+	}
+
+	fs := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	var (
+		batch  = fs.String("batch", "", "Required. The ID of the import batch to roll back.")
+		dryRun = fs.Bool("dry_run", false, "Optional. List the transactions that would be deleted, without deleting them.")
+	)
+	if err := fs.Parse(args[1:]); err != nil {
+		return fmt.Errorf("failed to parse flags: %v", err)
+	}
+
+	if *batch == "" {
+		return errors.New("no --batch was specified, but is required")
+	}
+
+	c, err := aplos.NewFromEnv(aplos.WithRateLimits(aplos.RateLimits{ReadRPS: 4, WriteRPS: 2}))
+	if err != nil {
+		return fmt.Errorf("failed to init Aplos client: %w", err)
+	}
+
+	var opts []importer.ImportOption
+	if *dryRun {
+		opts = append(opts, importer.WithDryRun())
+	}
+	results, err := importer.Rollback(context.Background(), c, *batch, opts...)
+	for _, r := range results {
+		if r.Err != nil {
+			fmt.Printf("Transaction %d: %s: %v\n", r.ID, r.Action, r.Err)
+		} else {
+			fmt.Printf("Transaction %d: %s\n", r.ID, r.Action)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to roll back batch %s: %w", *batch, err)
+	}
+
+	sum := importer.Summary(results)
+	verb := "Deleted"
+	if *dryRun {
+		verb = "Would delete"
+	}
+	fmt.Printf("%s %d transaction(s), %d failed\n", verb, sum[importer.Deleted], sum[importer.Failed])
+	if sum[importer.Failed] > 0 {
+		return errors.New("some transactions couldn't be deleted, fix the errors and run again")
+	}
+	return nil
+}
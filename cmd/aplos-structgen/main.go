@@ -0,0 +1,448 @@
+// Command aplos-structgen generates Go struct definitions from captured raw
+// Aplos API responses, to speed up adding support for new endpoints. Field
+// types are inferred from all of the given samples, date and timestamp
+// strings are mapped to the aplos.Date and aplos.Time types, and numeric
+// fields with names like "amount", "balance", or "total" are mapped to
+// aplos.Amount, so money isn't rounded through float64.
+//
+// Usage:
+//
+//	go run ./cmd/aplos-structgen --type=Contact --path=data.contacts contacts1.json contacts2.json
+//
+// The generated code is a starting point, it should be reviewed (e.g. for
+// fields that were always null in the samples) before being checked in.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+)
+
+func main() {
+	if err := run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(args []string) error {
+	if len(args) == 0 {
+		return errors.New("args cannot be empty")
+	}
+
+	fs := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	var (
+		typeName = fs.String("type", "", "Required. The name of the top-level Go type to generate, e.g. 'Contact'.")
+		path     = fs.String("path", "data", "Optional. A dot-separated path to the value in each response to generate types from, e.g. 'data.contacts'. Arrays along the path are descended into automatically.")
+		pkg      = fs.String("package", "aplos", "Optional. The package name of the generated code. If it isn't 'aplos', custom types are referenced as aplos.Date, aplos.Time, and aplos.Amount.")
+		out      = fs.String("out", "", "Optional. The file to write the generated code to, defaults to stdout.")
+	)
+	if err := fs.Parse(args[1:]); err != nil {
+		return fmt.Errorf("failed to parse flags: %v", err)
+	}
+
+	if *typeName == "" {
+		return errors.New("no --type was specified, but is required")
+	}
+	if fs.NArg() == 0 {
+		return errors.New("no response files were given")
+	}
+
+	var t *typ
+	for _, fn := range fs.Args() {
+		v, err := parseFile(fn)
+		if err != nil {
+			return fmt.Errorf("failed to parse %q: %w", fn, err)
+		}
+		for _, sub := range lookup(v, splitPath(*path)) {
+			t = merge(t, infer(sub))
+		}
+	}
+	if t == nil {
+		return fmt.Errorf("no values found at path %q", *path)
+	}
+	for t != nil && t.kind == kindArray {
+		t = t.elem
+	}
+	if t == nil || t.kind != kindObject {
+		return fmt.Errorf("value at path %q is not an object", *path)
+	}
+
+	g := &generator{pkg: *pkg, names: make(map[string]bool)}
+	g.define(*typeName, t)
+
+	src, err := g.source()
+	if err != nil {
+		return fmt.Errorf("failed to generate code: %w", err)
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+	if _, err := w.Write(src); err != nil {
+		return fmt.Errorf("failed to write generated code: %w", err)
+	}
+	return nil
+}
+
+// value is a parsed JSON value that, unlike map[string]interface{}, retains the
+// order of object keys so the generated fields match the API's ordering.
+type value struct {
+	obj  []keyValue
+	arr  []*value
+	kind kind
+}
+
+type keyValue struct {
+	key string
+	val *value
+}
+
+type kind int
+
+const (
+	kindNull kind = iota
+	kindBool
+	kindInt
+	kindFloat
+	kindString
+	kindDate
+	kindTime
+	kindObject
+	kindArray
+	kindMixed
+)
+
+func parseFile(fn string) (*value, error) {
+	dat, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(dat))
+	dec.UseNumber()
+	return parse(dec)
+}
+
+func parse(dec *json.Decoder) (*value, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := tok.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			v := &value{kind: kindObject}
+			for dec.More() {
+				kt, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				key, ok := kt.(string)
+				if !ok {
+					return nil, fmt.Errorf("unexpected object key %v", kt)
+				}
+				child, err := parse(dec)
+				if err != nil {
+					return nil, err
+				}
+				v.obj = append(v.obj, keyValue{key: key, val: child})
+			}
+			if _, err := dec.Token(); err != nil {
+				return nil, err
+			}
+			return v, nil
+		case '[':
+			v := &value{kind: kindArray}
+			for dec.More() {
+				child, err := parse(dec)
+				if err != nil {
+					return nil, err
+				}
+				v.arr = append(v.arr, child)
+			}
+			if _, err := dec.Token(); err != nil {
+				return nil, err
+			}
+			return v, nil
+		}
+		return nil, fmt.Errorf("unexpected delimiter %v", t)
+	case nil:
+		return &value{kind: kindNull}, nil
+	case bool:
+		return &value{kind: kindBool}, nil
+	case json.Number:
+		if _, err := t.Int64(); err == nil {
+			return &value{kind: kindInt}, nil
+		}
+		return &value{kind: kindFloat}, nil
+	case string:
+		switch {
+		case dateRE.MatchString(t):
+			return &value{kind: kindDate}, nil
+		case timeRE.MatchString(t):
+			return &value{kind: kindTime}, nil
+		}
+		return &value{kind: kindString}, nil
+	}
+	return nil, fmt.Errorf("unexpected token %v", tok)
+}
+
+var (
+	dateRE = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	// Matches the format parsed by aplos.Time, e.g. 2020-01-02T03:04:05.678-0700
+	timeRE = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?[+-]\d{4}$`)
+)
+
+// moneyRE matches the Go names of numeric fields that hold amounts of money.
+var moneyRE = regexp.MustCompile(`(?i)(amount|balance|total|income|expense|price|cost|fee)s?$`)
+
+func splitPath(p string) []string {
+	if p == "" {
+		return nil
+	}
+	return strings.Split(p, ".")
+}
+
+// lookup returns all values at the given path, descending into arrays.
+func lookup(v *value, path []string) []*value {
+	if v.kind == kindArray && len(path) > 0 {
+		var out []*value
+		for _, e := range v.arr {
+			out = append(out, lookup(e, path)...)
+		}
+		return out
+	}
+	if len(path) == 0 {
+		return []*value{v}
+	}
+	for _, kv := range v.obj {
+		if kv.key == path[0] {
+			return lookup(kv.val, path[1:])
+		}
+	}
+	return nil
+}
+
+// typ is the inferred type of one or more JSON values.
+type typ struct {
+	kind     kind
+	nullable bool
+	fields   []*field
+	elem     *typ
+}
+
+type field struct {
+	key string
+	t   *typ
+}
+
+func infer(v *value) *typ {
+	t := &typ{kind: v.kind}
+	switch v.kind {
+	case kindObject:
+		for _, kv := range v.obj {
+			t.fields = append(t.fields, &field{key: kv.key, t: infer(kv.val)})
+		}
+	case kindArray:
+		for _, e := range v.arr {
+			t.elem = merge(t.elem, infer(e))
+		}
+	}
+	return t
+}
+
+// merge combines two inferred types into one that can hold values of both.
+func merge(a, b *typ) *typ {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	case a.kind == kindNull:
+		b.nullable = true
+		return b
+	case b.kind == kindNull:
+		a.nullable = true
+		return a
+	}
+
+	out := &typ{kind: a.kind, nullable: a.nullable || b.nullable}
+	switch {
+	case a.kind == b.kind:
+	case isNumber(a.kind) && isNumber(b.kind):
+		out.kind = kindFloat
+	case isString(a.kind) && isString(b.kind):
+		out.kind = kindString
+	default:
+		out.kind = kindMixed
+		return out
+	}
+
+	switch out.kind {
+	case kindObject:
+		out.fields = a.fields
+		for _, bf := range b.fields {
+			found := false
+			for _, af := range out.fields {
+				if af.key == bf.key {
+					af.t = merge(af.t, bf.t)
+					found = true
+					break
+				}
+			}
+			if !found {
+				out.fields = append(out.fields, bf)
+			}
+		}
+	case kindArray:
+		out.elem = merge(a.elem, b.elem)
+	}
+	return out
+}
+
+func isNumber(k kind) bool {
+	return k == kindInt || k == kindFloat
+}
+
+func isString(k kind) bool {
+	return k == kindString || k == kindDate || k == kindTime
+}
+
+type generator struct {
+	pkg   string
+	names map[string]bool
+	buf   bytes.Buffer
+	defs  []string
+}
+
+func (g *generator) define(name string, t *typ) {
+	g.names[name] = true
+	// Reserve a slot so the parent type is emitted before any nested types.
+	idx := len(g.defs)
+	g.defs = append(g.defs, "")
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "type %s struct {\n", name)
+	for _, f := range t.fields {
+		goName := goIdent(f.key)
+		ft := g.goType(name, goName, f.t)
+		if strings.EqualFold(goName, f.key) {
+			fmt.Fprintf(&b, "\t%s %s\n", goName, ft)
+		} else {
+			fmt.Fprintf(&b, "\t%s %s `json:%q`\n", goName, ft, f.key)
+		}
+	}
+	b.WriteString("}\n")
+	g.defs[idx] = b.String()
+}
+
+func (g *generator) goType(parent, fieldName string, t *typ) string {
+	switch t.kind {
+	case kindBool:
+		return "bool"
+	case kindInt, kindFloat:
+		if moneyRE.MatchString(fieldName) {
+			return g.qualify("Amount")
+		}
+		if t.kind == kindInt {
+			return "int"
+		}
+		return "float64"
+	case kindString:
+		return "string"
+	case kindDate:
+		return g.qualify("Date")
+	case kindTime:
+		return g.qualify("Time")
+	case kindArray:
+		if t.elem == nil {
+			return "[]interface{}"
+		}
+		return "[]" + g.goType(parent, singular(fieldName), t.elem)
+	case kindObject:
+		name := fieldName
+		if g.names[name] {
+			name = parent + fieldName
+		}
+		g.define(name, t)
+		if t.nullable {
+			return "*" + name
+		}
+		return name
+	default:
+		return "interface{}"
+	}
+}
+
+func (g *generator) qualify(name string) string {
+	if g.pkg == "aplos" {
+		return name
+	}
+	return "aplos." + name
+}
+
+func (g *generator) source() ([]byte, error) {
+	fmt.Fprintf(&g.buf, "// Code generated by aplos-structgen. DO NOT EDIT.\n\npackage %s\n\n", g.pkg)
+	if g.pkg != "aplos" {
+		g.buf.WriteString("import \"github.com/Silicon-Ally/aplos\"\n\n")
+	}
+	for _, d := range g.defs {
+		g.buf.WriteString(d)
+		g.buf.WriteString("\n")
+	}
+	return format.Source(g.buf.Bytes())
+}
+
+var initialisms = map[string]string{
+	"id":   "ID",
+	"url":  "URL",
+	"api":  "API",
+	"ein":  "EIN",
+	"uuid": "UUID",
+}
+
+// goIdent converts a JSON key like "account_number" into a Go identifier like
+// "AccountNumber".
+func goIdent(key string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(key, func(r rune) bool {
+		return r == '_' || r == '-' || r == ' '
+	}) {
+		if i, ok := initialisms[strings.ToLower(part)]; ok {
+			b.WriteString(i)
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	if b.Len() == 0 {
+		return "Field"
+	}
+	return b.String()
+}
+
+func singular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies"):
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "ses"):
+		return strings.TrimSuffix(name, "es")
+	case strings.HasSuffix(name, "s") && !strings.HasSuffix(name, "ss"):
+		return strings.TrimSuffix(name, "s")
+	}
+	return name
+}
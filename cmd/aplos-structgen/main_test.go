@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestGoIdent(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "id", want: "ID"},
+		{in: "account_number", want: "AccountNumber"},
+		{in: "in_closed_period", want: "InClosedPeriod"},
+		{in: "contact_id", want: "ContactID"},
+		{in: "memo", want: "Memo"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			if got := goIdent(test.in); got != test.want {
+				t.Errorf("goIdent(%q) = %q, want %q", test.in, got, test.want)
+			}
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	samples := []string{
+		`{"data": {"contacts": [{"id": 1, "first_name": "Ada", "created": "2020-01-02T03:04:05.000-0700", "address": null}]}}`,
+		`{"data": {"contacts": [{"id": 2, "first_name": "Grace", "balance": 1.5, "rating": 4.5, "address": {"city": "Arlington"}, "emails": [{"email": "g@example.com"}]}]}}`,
+	}
+
+	var typ *typ
+	for _, s := range samples {
+		dec := json.NewDecoder(strings.NewReader(s))
+		dec.UseNumber()
+		v, err := parse(dec)
+		if err != nil {
+			t.Fatalf("parse: %v", err)
+		}
+		for _, sub := range lookup(v, splitPath("data.contacts")) {
+			typ = merge(typ, infer(sub))
+		}
+	}
+
+	for typ.kind == kindArray {
+		typ = typ.elem
+	}
+
+	g := &generator{pkg: "aplos", names: make(map[string]bool)}
+	g.define("Contact", typ)
+	got, err := g.source()
+	if err != nil {
+		t.Fatalf("source: %v", err)
+	}
+
+	want := `// Code generated by aplos-structgen. DO NOT EDIT.
+
+package aplos
+
+type Contact struct {
+	ID        int
+	FirstName string ` + "`json:\"first_name\"`" + `
+	Created   Time
+	Address   *Address
+	Balance   Amount
+	Rating    float64
+	Emails    []Email
+}
+
+type Address struct {
+	City string
+}
+
+type Email struct {
+	Email string
+}
+`
+	if string(got) != want {
+		t.Errorf("generated code = \n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,206 @@
+package aplos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ContactType is the kind of entity a Contact represents.
+type ContactType string
+
+const (
+	ContactTypeIndividual ContactType = "individual"
+	ContactTypeCompany    ContactType = "company"
+)
+
+// Contact is a person or organization, like a donor or vendor. Contact has
+// explicit JSON tags so that it round-trips through the API's format when used
+// with CreateContact and UpdateContact.
+type Contact struct {
+	ID          int         `json:"id,omitempty"`
+	Type        ContactType `json:"type"`
+	CompanyName string      `json:"companyname,omitempty"`
+	Title       string      `json:"title,omitempty"`
+	FirstName   string      `json:"firstname,omitempty"`
+	MiddleName  string      `json:"middlename,omitempty"`
+	LastName    string      `json:"lastname,omitempty"`
+	Suffix      string      `json:"suffix,omitempty"`
+	// Email is the contact's primary email address. The full list is in Emails.
+	Email string `json:"email,omitempty"`
+
+	Addresses []ContactAddress `json:"addresses,omitempty"`
+	Phones    []ContactPhone   `json:"phones,omitempty"`
+	Emails    []ContactEmail   `json:"emails,omitempty"`
+}
+
+// Name returns the contact's display name: the company name for companies,
+// and otherwise the first and last name, falling back to the company name. It
+// returns "" if the contact has no name.
+func (c Contact) Name() string {
+	if c.Type == ContactTypeCompany && c.CompanyName != "" {
+		return c.CompanyName
+	}
+	if n := strings.TrimSpace(c.FirstName + " " + c.LastName); n != "" {
+		return n
+	}
+	return c.CompanyName
+}
+
+// ContactAddress is a mailing address for a Contact.
+type ContactAddress struct {
+	ID         int    `json:"id,omitempty"`
+	Type       string `json:"type,omitempty"`
+	Address1   string `json:"address1,omitempty"`
+	Address2   string `json:"address2,omitempty"`
+	City       string `json:"city,omitempty"`
+	State      string `json:"state,omitempty"`
+	PostalCode string `json:"postal_code,omitempty"`
+	Country    string `json:"country,omitempty"`
+	IsPrimary  bool   `json:"is_primary"`
+}
+
+// ContactPhone is a phone number for a Contact.
+type ContactPhone struct {
+	ID        int    `json:"id,omitempty"`
+	Type      string `json:"type,omitempty"`
+	Number    string `json:"telephone"`
+	IsPrimary bool   `json:"is_primary"`
+}
+
+// ContactEmail is an email address for a Contact.
+type ContactEmail struct {
+	ID        int    `json:"id,omitempty"`
+	Type      string `json:"type,omitempty"`
+	Address   string `json:"address"`
+	IsPrimary bool   `json:"is_primary"`
+}
+
+type getContactResponse struct {
+	Version string
+	Status  int
+	Data    getContactResponseData
+}
+
+type getContactResponseData struct {
+	Contact Contact
+}
+
+// Contact returns the contact with the given ID.
+func (c *Client) Contact(ctx context.Context, id int) (*Contact, error) {
+	var gResp getContactResponse
+	if err := c.get(ctx, "/contacts/"+strconv.Itoa(id), nil, &gResp); err != nil {
+		return nil, fmt.Errorf("failed to get contact: %w", err)
+	}
+
+	return &gResp.Data.Contact, nil
+}
+
+// CreateContact creates a new contact in Aplos, returning the created contact
+// with its server-assigned ID. The ID of the given contact is ignored.
+func (c *Client) CreateContact(ctx context.Context, contact Contact) (*Contact, error) {
+	if contact.Type == "" {
+		return nil, errors.New("contact type must be set")
+	}
+	contact.ID = 0
+
+	var gResp getContactResponse
+	if err := c.send(ctx, http.MethodPost, "/contacts", contact, &gResp); err != nil {
+		return nil, fmt.Errorf("failed to create contact: %w", err)
+	}
+
+	return &gResp.Data.Contact, nil
+}
+
+// UpdateContact replaces the contact with the ID of the given contact, and
+// returns the updated contact.
+func (c *Client) UpdateContact(ctx context.Context, contact Contact) (*Contact, error) {
+	if contact.ID == 0 {
+		return nil, errors.New("contact ID must be set")
+	}
+
+	var gResp getContactResponse
+	if err := c.send(ctx, http.MethodPut, "/contacts/"+strconv.Itoa(contact.ID), contact, &gResp); err != nil {
+		return nil, fmt.Errorf("failed to update contact: %w", err)
+	}
+
+	return &gResp.Data.Contact, nil
+}
+
+type listContactsResponse struct {
+	Version string
+	Status  int
+	listPage
+	Data listContactsResponseData
+}
+
+type listContactsResponseData struct {
+	Contacts []Contact
+}
+
+type listContactsOpts struct {
+	name        *string
+	email       *string
+	contactType *ContactType
+}
+
+// WithContactName filters contacts to those whose name matches the given
+// string.
+func WithContactName(name string) ListContactOption {
+	return func(o *listContactsOpts) {
+		o.name = &name
+	}
+}
+
+// WithContactEmail filters contacts to those with the given email address.
+func WithContactEmail(email string) ListContactOption {
+	return func(o *listContactsOpts) {
+		o.email = &email
+	}
+}
+
+// WithContactType filters contacts to those of the given type.
+func WithContactType(typ ContactType) ListContactOption {
+	return func(o *listContactsOpts) {
+		o.contactType = &typ
+	}
+}
+
+type ListContactOption func(*listContactsOpts)
+
+// Contacts returns a list of contacts satisfying the given options. All pages
+// of results are loaded.
+func (c *Client) Contacts(ctx context.Context, opts ...ListContactOption) ([]Contact, error) {
+	o := &listContactsOpts{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	q := url.Values{}
+	if o.name != nil {
+		q.Add("f_name", *o.name)
+	}
+	if o.email != nil {
+		q.Add("f_email", *o.email)
+	}
+	if o.contactType != nil {
+		q.Add("f_type", string(*o.contactType))
+	}
+
+	var contacts []Contact
+	err := forEachPage(ctx, c, c.url("/contacts", q), func(r *listContactsResponse) bool {
+		if contacts == nil {
+			contacts = make([]Contact, 0, r.Meta.RecordCount)
+		}
+		contacts = append(contacts, r.Data.Contacts...)
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contacts: %w", err)
+	}
+	return contacts, nil
+}
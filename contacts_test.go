@@ -0,0 +1,139 @@
+package aplos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestContacts(t *testing.T) {
+	var gotQuery string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/contacts" {
+			t.Errorf("request path = %q, want /contacts", r.URL.Path)
+		}
+		gotQuery = r.URL.RawQuery
+		fmt.Fprint(w, `{"status": 200, "data": {"contacts": [{"id": 1, "type": "individual", "firstname": "Ada", "lastname": "Lovelace", "email": "ada@example.com"}]}}`)
+	}))
+
+	got, err := c.Contacts(context.Background(),
+		WithContactName("Lovelace"),
+		WithContactEmail("ada@example.com"),
+		WithContactType(ContactTypeIndividual),
+	)
+	if err != nil {
+		t.Fatalf("Contacts: %v", err)
+	}
+
+	if want := "f_email=ada%40example.com&f_name=Lovelace&f_type=individual"; gotQuery != want {
+		t.Errorf("query = %q, want %q", gotQuery, want)
+	}
+	want := []Contact{
+		{ID: 1, Type: ContactTypeIndividual, FirstName: "Ada", LastName: "Lovelace", Email: "ada@example.com"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Contacts() = %+v, want %+v", got, want)
+	}
+}
+
+func TestContact(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/contacts/42" {
+			t.Errorf("request path = %q, want /contacts/42", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"status": 200, "data": {"contact": {"id": 42, "type": "company", "companyname": "Acme"}}}`)
+	}))
+
+	got, err := c.Contact(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("Contact: %v", err)
+	}
+	want := &Contact{ID: 42, Type: ContactTypeCompany, CompanyName: "Acme"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Contact() = %+v, want %+v", got, want)
+	}
+}
+
+const contactJSON = `{
+  "id": 7,
+  "type": "individual",
+  "firstname": "Grace",
+  "lastname": "Hopper",
+  "email": "grace@example.com",
+  "addresses": [{"id": 1, "type": "home", "address1": "1 Main St", "city": "Arlington", "state": "VA", "postal_code": "22201", "country": "US", "is_primary": true}],
+  "phones": [{"id": 2, "type": "mobile", "telephone": "555-0100", "is_primary": true}],
+  "emails": [{"id": 3, "type": "personal", "address": "grace@example.com", "is_primary": true}]
+}`
+
+func TestContactJSONRoundTrip(t *testing.T) {
+	var c Contact
+	if err := json.Unmarshal([]byte(contactJSON), &c); err != nil {
+		t.Fatalf("failed to unmarshal contact: %v", err)
+	}
+	got, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("failed to marshal contact: %v", err)
+	}
+
+	var gotMap, wantMap map[string]interface{}
+	if err := json.Unmarshal(got, &gotMap); err != nil {
+		t.Fatalf("failed to unmarshal marshaled contact: %v", err)
+	}
+	if err := json.Unmarshal([]byte(contactJSON), &wantMap); err != nil {
+		t.Fatalf("failed to unmarshal want contact: %v", err)
+	}
+	if !reflect.DeepEqual(gotMap, wantMap) {
+		t.Errorf("round-tripped contact = %s, want %s", got, contactJSON)
+	}
+}
+
+func TestCreateContact(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/contacts" {
+			t.Errorf("request = %s %s, want POST /contacts", r.Method, r.URL.Path)
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		if _, ok := body["id"]; ok {
+			t.Errorf("request body included an ID: %v", body)
+		}
+		if body["firstname"] != "Grace" {
+			t.Errorf("request firstname = %v, want Grace", body["firstname"])
+		}
+		fmt.Fprintf(w, `{"status": 200, "data": {"contact": %s}}`, contactJSON)
+	}))
+
+	got, err := c.CreateContact(context.Background(), Contact{
+		ID:        123,
+		Type:      ContactTypeIndividual,
+		FirstName: "Grace",
+		LastName:  "Hopper",
+	})
+	if err != nil {
+		t.Fatalf("CreateContact: %v", err)
+	}
+	if got.ID != 7 || len(got.Addresses) != 1 || got.Addresses[0].PostalCode != "22201" {
+		t.Errorf("CreateContact() = %+v, want the contact from the response", got)
+	}
+}
+
+func TestUpdateContact(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/contacts/7" {
+			t.Errorf("request = %s %s, want PUT /contacts/7", r.Method, r.URL.Path)
+		}
+		fmt.Fprintf(w, `{"status": 200, "data": {"contact": %s}}`, contactJSON)
+	}))
+
+	if _, err := c.UpdateContact(context.Background(), Contact{Type: ContactTypeIndividual}); err == nil {
+		t.Error("UpdateContact without an ID returned no error, want one")
+	}
+	if _, err := c.UpdateContact(context.Background(), Contact{ID: 7, Type: ContactTypeIndividual}); err != nil {
+		t.Errorf("UpdateContact: %v", err)
+	}
+}
@@ -0,0 +1,139 @@
+//go:build contract
+
+// Contract tests check this package's assumptions about serialization,
+// filters, and pagination against a real Aplos organization, ideally a
+// sandbox. They only read data. To run them:
+//
+//	APLOS_CLIENT_ID=... APLOS_KEY_FILE=/path/to/key go test -tags contract -run Contract ./...
+//
+// APLOS_BASE_URL optionally overrides the API base URL.
+package aplos_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Silicon-Ally/aplos"
+)
+
+func contractClient(t *testing.T) *aplos.Client {
+	t.Helper()
+	clientID, keyFile := os.Getenv("APLOS_CLIENT_ID"), os.Getenv("APLOS_KEY_FILE")
+	if clientID == "" || keyFile == "" {
+		t.Skip("APLOS_CLIENT_ID and APLOS_KEY_FILE must be set to run contract tests")
+	}
+	key, err := aplos.LoadPrivateKeyFromFile(keyFile)
+	if err != nil {
+		t.Fatalf("failed to load key: %v", err)
+	}
+	opts := []aplos.Option{aplos.WithRetry(3, time.Second)}
+	if u := os.Getenv("APLOS_BASE_URL"); u != "" {
+		opts = append(opts, aplos.WithBaseURL(u))
+	}
+	c, err := aplos.New(clientID, key, opts...)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return c
+}
+
+func TestContractSchema(t *testing.T) {
+	c := contractClient(t)
+	drifts, err := c.SchemaDrift(context.Background())
+	if err != nil {
+		t.Fatalf("SchemaDrift: %v", err)
+	}
+	for _, d := range drifts {
+		t.Errorf("schema drift on %s: added %v, removed %v", d.Endpoint, d.Added, d.Removed)
+	}
+}
+
+func TestContractTransactionFilters(t *testing.T) {
+	c := contractClient(t)
+	ctx := context.Background()
+
+	txns, err := c.Transactions(ctx, aplos.WithRangeStart(2000, time.January, 1), aplos.WithMaxResults(50))
+	if err != nil {
+		t.Fatalf("Transactions: %v", err)
+	}
+	if len(txns) == 0 {
+		t.Skip("organization has no transactions")
+	}
+	d := txns[0].Date
+
+	inRange, err := c.Transactions(ctx, aplos.WithRangeStart(d.Year, d.Month, d.Day), aplos.WithRangeEnd(d.Year, d.Month, d.Day))
+	if err != nil {
+		t.Fatalf("Transactions in range: %v", err)
+	}
+	if len(inRange) == 0 {
+		t.Errorf("no transactions on %v, want at least transaction %d", d, txns[0].ID)
+	}
+	for _, txn := range inRange {
+		if txn.Date != d {
+			t.Errorf("transaction %d dated %v, outside the filtered range %v", txn.ID, txn.Date, d)
+		}
+	}
+
+	full, err := c.Transaction(ctx, txns[0].ID)
+	if err != nil {
+		t.Fatalf("Transaction: %v", err)
+	}
+	if len(full.Lines) == 0 {
+		t.Fatalf("transaction %d has no lines", full.ID)
+	}
+	var sum aplos.Amount
+	for _, l := range full.Lines {
+		sum += l.Amount
+	}
+	if sum != 0 {
+		t.Errorf("transaction %d lines sum to %v, want debits positive and credits negative, summing to zero", full.ID, sum)
+	}
+
+	acct := full.Lines[0].Account.AccountNumber
+	byAccount, err := c.Transactions(ctx, aplos.WithAccountNumber(acct), aplos.WithRangeStart(d.Year, d.Month, d.Day), aplos.WithRangeEnd(d.Year, d.Month, d.Day))
+	if err != nil {
+		t.Fatalf("Transactions by account: %v", err)
+	}
+	found := false
+	for _, txn := range byAccount {
+		found = found || txn.ID == full.ID
+	}
+	if !found {
+		t.Errorf("filtering by account %d didn't return transaction %d, which has a line in it", acct, full.ID)
+	}
+}
+
+func TestContractPagination(t *testing.T) {
+	c := contractClient(t)
+	ctx := context.Background()
+
+	all, err := c.Accounts(ctx)
+	if err != nil {
+		t.Fatalf("Accounts: %v", err)
+	}
+	seen := make(map[int]bool)
+	n := 0
+	for acct, err := range c.AccountsIter(ctx) {
+		if err != nil {
+			t.Fatalf("AccountsIter: %v", err)
+		}
+		if seen[acct.AccountNumber] {
+			t.Errorf("account %d returned on more than one page", acct.AccountNumber)
+		}
+		seen[acct.AccountNumber] = true
+		n++
+	}
+	if n != len(all) {
+		t.Errorf("AccountsIter returned %d accounts, Accounts returned %d", n, len(all))
+	}
+
+	txns, err := c.Transactions(ctx, aplos.WithRangeStart(2000, time.January, 1), aplos.WithMaxResults(3))
+	if err != nil {
+		t.Fatalf("Transactions: %v", err)
+	}
+	if len(txns) > 3 {
+		t.Errorf("WithMaxResults(3) returned %d transactions", len(txns))
+	}
+}
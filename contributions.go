@@ -0,0 +1,226 @@
+package aplos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// PaymentMethod is how a contribution was paid.
+type PaymentMethod string
+
+const (
+	PaymentMethodCash       PaymentMethod = "cash"
+	PaymentMethodCheck      PaymentMethod = "check"
+	PaymentMethodCreditCard PaymentMethod = "credit_card"
+	PaymentMethodACH        PaymentMethod = "ach"
+	PaymentMethodOther      PaymentMethod = "other"
+)
+
+// Contribution is a donation received from a contact.
+type Contribution struct {
+	ID            int           `json:"id,omitempty"`
+	Date          Date          `json:"date"`
+	Amount        Amount        `json:"amount"`
+	PaymentMethod PaymentMethod `json:"payment_method"`
+	// CheckNumber is set for contributions paid by check.
+	CheckNumber string  `json:"check_number,omitempty"`
+	Contact     Contact `json:"contact"`
+	Purpose     Purpose `json:"purpose"`
+	Note        string  `json:"note,omitempty"`
+	// Batch is the deposit batch the contribution was recorded in, if any.
+	Batch *ContributionBatch `json:"batch,omitempty"`
+	// Funds is how the contribution is split across funds. If empty, the whole
+	// amount goes to the purpose's default fund.
+	Funds []ContributionFund `json:"funds,omitempty"`
+}
+
+// ContributionFund is the portion of a contribution allocated to a fund.
+type ContributionFund struct {
+	Fund   Fund   `json:"fund"`
+	Amount Amount `json:"amount"`
+}
+
+// ContributionBatch is a group of contributions entered, and typically
+// deposited, together.
+type ContributionBatch struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name,omitempty"`
+	Date      Date   `json:"date"`
+	Deposited bool   `json:"is_deposited"`
+}
+
+type getContributionResponse struct {
+	Version string
+	Status  int
+	Data    getContributionResponseData
+}
+
+type getContributionResponseData struct {
+	Contribution Contribution
+}
+
+// Contribution returns the contribution with the given ID.
+func (c *Client) Contribution(ctx context.Context, id int) (*Contribution, error) {
+	var gResp getContributionResponse
+	if err := c.get(ctx, "/contributions/"+strconv.Itoa(id), nil, &gResp); err != nil {
+		return nil, fmt.Errorf("failed to get contribution: %w", err)
+	}
+
+	return &gResp.Data.Contribution, nil
+}
+
+// contributionRequest is the body of a request to create a contribution, which
+// refers to related records by ID.
+type contributionRequest struct {
+	Date          Date                      `json:"date"`
+	Amount        Amount                    `json:"amount"`
+	PaymentMethod PaymentMethod             `json:"payment_method"`
+	CheckNumber   string                    `json:"check_number,omitempty"`
+	ContactID     int                       `json:"contact_id"`
+	PurposeID     int                       `json:"purpose_id,omitempty"`
+	Note          string                    `json:"note,omitempty"`
+	Funds         []contributionFundRequest `json:"funds,omitempty"`
+}
+
+type contributionFundRequest struct {
+	FundID int    `json:"fund_id"`
+	Amount Amount `json:"amount"`
+}
+
+// CreateContribution records a new contribution in Aplos, returning the created
+// contribution with its server-assigned ID. Related records are referenced by
+// ID, so the contribution's Contact.ID must be set, as must Purpose.ID and
+// each Funds[].Fund.ID when used. If a fund split is given, it must add up to
+// the contribution's amount. The contribution's ID and Batch are ignored.
+func (c *Client) CreateContribution(ctx context.Context, contrib Contribution) (*Contribution, error) {
+	if contrib.Contact.ID == 0 {
+		return nil, errors.New("contribution contact ID must be set")
+	}
+	if contrib.Date == (Date{}) {
+		return nil, errors.New("contribution date must be set")
+	}
+	if contrib.Amount <= 0 {
+		return nil, fmt.Errorf("contribution amount must be positive, was %s", contrib.Amount)
+	}
+
+	req := contributionRequest{
+		Date:          contrib.Date,
+		Amount:        contrib.Amount,
+		PaymentMethod: contrib.PaymentMethod,
+		CheckNumber:   contrib.CheckNumber,
+		ContactID:     contrib.Contact.ID,
+		PurposeID:     contrib.Purpose.ID,
+		Note:          contrib.Note,
+	}
+	var total Amount
+	for _, f := range contrib.Funds {
+		if f.Fund.ID == 0 {
+			return nil, errors.New("contribution fund ID must be set")
+		}
+		total += f.Amount
+		req.Funds = append(req.Funds, contributionFundRequest{FundID: f.Fund.ID, Amount: f.Amount})
+	}
+	if len(contrib.Funds) > 0 && total != contrib.Amount {
+		return nil, fmt.Errorf("contribution fund split adds up to %s, want %s", total, contrib.Amount)
+	}
+
+	var gResp getContributionResponse
+	if err := c.send(ctx, http.MethodPost, "/contributions", req, &gResp); err != nil {
+		return nil, fmt.Errorf("failed to create contribution: %w", err)
+	}
+
+	return &gResp.Data.Contribution, nil
+}
+
+type listContributionsResponse struct {
+	Version string
+	Status  int
+	listPage
+	Data listContributionsResponseData
+}
+
+type listContributionsResponseData struct {
+	Contributions []Contribution
+}
+
+type listContributionsOpts struct {
+	rangeStart *Date
+	rangeEnd   *Date
+	purposeID  *int
+	contactID  *int
+}
+
+// WithContributionRangeStart filters contributions to those made on or after
+// the given date.
+func WithContributionRangeStart(year int, month time.Month, day int) ListContributionOption {
+	return func(o *listContributionsOpts) {
+		o.rangeStart = &Date{Year: year, Month: month, Day: day}
+	}
+}
+
+// WithContributionRangeEnd filters contributions to those made on or before the
+// given date.
+func WithContributionRangeEnd(year int, month time.Month, day int) ListContributionOption {
+	return func(o *listContributionsOpts) {
+		o.rangeEnd = &Date{Year: year, Month: month, Day: day}
+	}
+}
+
+// WithContributionPurpose filters contributions to those given for the purpose
+// with the given ID.
+func WithContributionPurpose(purposeID int) ListContributionOption {
+	return func(o *listContributionsOpts) {
+		o.purposeID = &purposeID
+	}
+}
+
+// WithContributionContact filters contributions to those from the contact with
+// the given ID.
+func WithContributionContact(contactID int) ListContributionOption {
+	return func(o *listContributionsOpts) {
+		o.contactID = &contactID
+	}
+}
+
+type ListContributionOption func(*listContributionsOpts)
+
+// Contributions returns a list of contributions satisfying the given options.
+// All pages of results are loaded.
+func (c *Client) Contributions(ctx context.Context, opts ...ListContributionOption) ([]Contribution, error) {
+	o := &listContributionsOpts{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	q := url.Values{}
+	if o.rangeStart != nil {
+		q.Add("f_rangestart", o.rangeStart.String())
+	}
+	if o.rangeEnd != nil {
+		q.Add("f_rangeend", o.rangeEnd.String())
+	}
+	if o.purposeID != nil {
+		q.Add("f_purpose", strconv.Itoa(*o.purposeID))
+	}
+	if o.contactID != nil {
+		q.Add("f_contact", strconv.Itoa(*o.contactID))
+	}
+
+	var contribs []Contribution
+	err := forEachPage(ctx, c, c.url("/contributions", q), func(r *listContributionsResponse) bool {
+		if contribs == nil {
+			contribs = make([]Contribution, 0, r.Meta.RecordCount)
+		}
+		contribs = append(contribs, r.Data.Contributions...)
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contributions: %w", err)
+	}
+	return contribs, nil
+}
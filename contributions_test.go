@@ -0,0 +1,159 @@
+package aplos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestContributions(t *testing.T) {
+	var gotQuery string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/contributions" {
+			t.Errorf("request path = %q, want /contributions", r.URL.Path)
+		}
+		gotQuery = r.URL.RawQuery
+		fmt.Fprint(w, `{"status": 200, "data": {"contributions": [{
+			"id": 10,
+			"date": "2023-02-14",
+			"amount": 250,
+			"payment_method": "check",
+			"check_number": "5512",
+			"contact": {"id": 7, "type": "individual", "firstname": "Grace", "lastname": "Hopper"},
+			"purpose": {"id": 3, "name": "Annual Fund"},
+			"batch": {"id": 99, "name": "Feb deposit", "date": "2023-02-15", "is_deposited": true}
+		}]}}`)
+	}))
+
+	got, err := c.Contributions(context.Background(),
+		WithContributionRangeStart(2023, time.January, 1),
+		WithContributionRangeEnd(2023, time.December, 31),
+		WithContributionPurpose(3),
+		WithContributionContact(7),
+	)
+	if err != nil {
+		t.Fatalf("Contributions: %v", err)
+	}
+
+	if want := "f_contact=7&f_purpose=3&f_rangeend=2023-12-31&f_rangestart=2023-01-01"; gotQuery != want {
+		t.Errorf("query = %q, want %q", gotQuery, want)
+	}
+	want := []Contribution{{
+		ID:            10,
+		Date:          d(2023, time.February, 14),
+		Amount:        250_00,
+		PaymentMethod: PaymentMethodCheck,
+		CheckNumber:   "5512",
+		Contact:       Contact{ID: 7, Type: ContactTypeIndividual, FirstName: "Grace", LastName: "Hopper"},
+		Purpose:       Purpose{ID: 3, Name: "Annual Fund"},
+		Batch:         &ContributionBatch{ID: 99, Name: "Feb deposit", Date: d(2023, time.February, 15), Deposited: true},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Contributions() = %+v, want %+v", got, want)
+	}
+}
+
+func TestContribution(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/contributions/10" {
+			t.Errorf("request path = %q, want /contributions/10", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"status": 200, "data": {"contribution": {"id": 10, "date": "2023-02-14", "amount": 25.5, "payment_method": "cash"}}}`)
+	}))
+
+	got, err := c.Contribution(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("Contribution: %v", err)
+	}
+	if got.ID != 10 || got.Amount != 25_50 || got.PaymentMethod != PaymentMethodCash {
+		t.Errorf("Contribution() = %+v, want contribution 10", got)
+	}
+}
+
+func TestCreateContribution(t *testing.T) {
+	var gotBody map[string]interface{}
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/contributions" {
+			t.Errorf("request = %s %s, want POST /contributions", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		fmt.Fprint(w, `{"status": 200, "data": {"contribution": {"id": 11, "date": "2023-03-01", "amount": 100, "payment_method": "credit_card"}}}`)
+	}))
+
+	contrib := Contribution{
+		Date:          d(2023, time.March, 1),
+		Amount:        100_00,
+		PaymentMethod: PaymentMethodCreditCard,
+		Contact:       Contact{ID: 7, FirstName: "Grace"},
+		Purpose:       Purpose{ID: 3},
+		Note:          "Online donation",
+		Funds: []ContributionFund{
+			{Fund: Fund{ID: 1}, Amount: 60_00},
+			{Fund: Fund{ID: 2}, Amount: 40_00},
+		},
+	}
+	got, err := c.CreateContribution(context.Background(), contrib)
+	if err != nil {
+		t.Fatalf("CreateContribution: %v", err)
+	}
+	if got.ID != 11 {
+		t.Errorf("CreateContribution() = %+v, want the contribution from the response", got)
+	}
+
+	wantBody := map[string]interface{}{
+		"date":           "2023-03-01",
+		"amount":         100.0,
+		"payment_method": "credit_card",
+		"contact_id":     7.0,
+		"purpose_id":     3.0,
+		"note":           "Online donation",
+		"funds": []interface{}{
+			map[string]interface{}{"fund_id": 1.0, "amount": 60.0},
+			map[string]interface{}{"fund_id": 2.0, "amount": 40.0},
+		},
+	}
+	if !reflect.DeepEqual(gotBody, wantBody) {
+		t.Errorf("request body = %v, want %v", gotBody, wantBody)
+	}
+}
+
+func TestCreateContributionValidation(t *testing.T) {
+	valid := Contribution{
+		Date:    d(2023, time.March, 1),
+		Amount:  100_00,
+		Contact: Contact{ID: 7},
+	}
+	tests := []struct {
+		desc   string
+		modify func(*Contribution)
+	}{
+		{desc: "no contact", modify: func(c *Contribution) { c.Contact.ID = 0 }},
+		{desc: "no date", modify: func(c *Contribution) { c.Date = Date{} }},
+		{desc: "zero amount", modify: func(c *Contribution) { c.Amount = 0 }},
+		{desc: "fund without ID", modify: func(c *Contribution) {
+			c.Funds = []ContributionFund{{Amount: 100_00}}
+		}},
+		{desc: "unbalanced split", modify: func(c *Contribution) {
+			c.Funds = []ContributionFund{{Fund: Fund{ID: 1}, Amount: 60_00}, {Fund: Fund{ID: 2}, Amount: 30_00}}
+		}},
+	}
+
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			contrib := valid
+			test.modify(&contrib)
+			if _, err := c.CreateContribution(context.Background(), contrib); err == nil {
+				t.Error("CreateContribution() returned no error, want one")
+			}
+		})
+	}
+}
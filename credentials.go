@@ -0,0 +1,146 @@
+package aplos
+
+import (
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Credentials is the format of a credentials file, see NewFromCredentialsFile.
+// It holds everything needed to create a client in one place, so that a
+// deployment can mount a single secret, like:
+//
+//	{"client_id": "...", "key": "MIIEvQIBADANBg..."}
+type Credentials struct {
+	ClientID string `json:"client_id"`
+	// Key is the private key, either as in a key file downloaded from the Aplos
+	// UI, i.e. base64-encoded PKCS8, or PEM-encoded.
+	Key string `json:"key"`
+	// BaseURL optionally overrides the API base URL, see WithBaseURL.
+	BaseURL string `json:"base_url,omitempty"`
+}
+
+// ParseCredentials parses credentials in the format described by
+// Credentials.
+func ParseCredentials(dat []byte) (*Credentials, error) {
+	var creds Credentials
+	if err := json.Unmarshal(dat, &creds); err != nil {
+		return nil, fmt.Errorf("failed to parse credentials: %w", err)
+	}
+	if creds.ClientID == "" {
+		return nil, errors.New("credentials have no client_id")
+	}
+	if creds.Key == "" {
+		return nil, errors.New("credentials have no key")
+	}
+	return &creds, nil
+}
+
+// PrivateKey parses the credentials' key.
+func (c *Credentials) PrivateKey() (*rsa.PrivateKey, error) {
+	return parseKeyFile([]byte(c.Key))
+}
+
+// NewFromCredentials returns a client authenticated with creds. If creds has a
+// BaseURL, it's applied before opts.
+func NewFromCredentials(creds *Credentials, opts ...Option) (*Client, error) {
+	key, err := creds.PrivateKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load key: %w", err)
+	}
+	if creds.BaseURL != "" {
+		opts = append([]Option{WithBaseURL(creds.BaseURL)}, opts...)
+	}
+	return New(creds.ClientID, key, opts...)
+}
+
+// NewFromCredentialsFile returns a client authenticated with the credentials
+// in the given file, in the JSON format described by Credentials.
+func NewFromCredentialsFile(fp string, opts ...Option) (*Client, error) {
+	creds, err := loadCredentialsFile(fp)
+	if err != nil {
+		return nil, err
+	}
+	return NewFromCredentials(creds, opts...)
+}
+
+func loadCredentialsFile(fp string) (*Credentials, error) {
+	dat, err := os.ReadFile(fp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials file: %w", err)
+	}
+	creds, err := ParseCredentials(dat)
+	if err != nil {
+		return nil, fmt.Errorf("invalid credentials file %s: %w", fp, err)
+	}
+	return creds, nil
+}
+
+// Environment variables read by NewFromEnv.
+const (
+	EnvCredentials     = "APLOS_CREDENTIALS"
+	EnvCredentialsFile = "APLOS_CREDENTIALS_FILE"
+	EnvClientID        = "APLOS_CLIENT_ID"
+	EnvKey             = "APLOS_KEY"
+	EnvKeyFile         = "APLOS_KEY_FILE"
+	EnvBaseURL         = "APLOS_BASE_URL"
+)
+
+// ErrNoCredentials is returned by NewFromEnv when none of the environment
+// variables it reads credentials from are set.
+var ErrNoCredentials = errors.New("no Aplos credentials in the environment")
+
+// NewFromEnv returns a client authenticated with credentials from the
+// environment, checking, in order:
+//
+//   - APLOS_CREDENTIALS, holding credentials as JSON, as described by
+//     Credentials.
+//   - APLOS_CREDENTIALS_FILE, the path to a credentials file.
+//   - APLOS_CLIENT_ID, with the key in APLOS_KEY, or in the key file at
+//     APLOS_KEY_FILE.
+//
+// APLOS_BASE_URL, if set, overrides the API base URL of any of them. It's
+// applied before opts.
+func NewFromEnv(opts ...Option) (*Client, error) {
+	creds, err := credentialsFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	if u := os.Getenv(EnvBaseURL); u != "" {
+		creds.BaseURL = u
+	}
+	return NewFromCredentials(creds, opts...)
+}
+
+func credentialsFromEnv() (*Credentials, error) {
+	if v := os.Getenv(EnvCredentials); v != "" {
+		creds, err := ParseCredentials([]byte(v))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", EnvCredentials, err)
+		}
+		return creds, nil
+	}
+	if fp := os.Getenv(EnvCredentialsFile); fp != "" {
+		return loadCredentialsFile(fp)
+	}
+
+	clientID := os.Getenv(EnvClientID)
+	if clientID == "" {
+		return nil, ErrNoCredentials
+	}
+	key := os.Getenv(EnvKey)
+	if key == "" {
+		fp := os.Getenv(EnvKeyFile)
+		if fp == "" {
+			return nil, fmt.Errorf("%s is set, but neither %s nor %s is", EnvClientID, EnvKey, EnvKeyFile)
+		}
+		dat, err := os.ReadFile(fp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key file: %w", err)
+		}
+		key = string(dat)
+	}
+	return &Credentials{ClientID: clientID, Key: key}, nil
+}
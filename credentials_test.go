@@ -0,0 +1,114 @@
+package aplos_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Silicon-Ally/aplos"
+	"github.com/Silicon-Ally/aplos/aplostest"
+)
+
+// clearCredentialsEnv unsets the environment variables read by NewFromEnv for
+// the duration of the test.
+func clearCredentialsEnv(t *testing.T) {
+	for _, k := range []string{aplos.EnvCredentials, aplos.EnvCredentialsFile, aplos.EnvClientID, aplos.EnvKey, aplos.EnvKeyFile, aplos.EnvBaseURL} {
+		t.Setenv(k, "")
+	}
+}
+
+func TestNewFromEnv(t *testing.T) {
+	srv := aplostest.NewServer()
+	defer srv.Close()
+	srv.Load(aplostest.SmallNonprofit())
+	keyFile, err := srv.Credentials.KeyFile()
+	if err != nil {
+		t.Fatalf("KeyFile: %v", err)
+	}
+	blob, err := json.Marshal(aplos.Credentials{ClientID: srv.Credentials.ClientID, Key: string(keyFile)})
+	if err != nil {
+		t.Fatalf("failed to marshal credentials: %v", err)
+	}
+	dir := t.TempDir()
+	credsPath := filepath.Join(dir, "credentials.json")
+	keyPath := filepath.Join(dir, "key")
+	if err := os.WriteFile(credsPath, blob, 0o600); err != nil {
+		t.Fatalf("failed to write credentials file: %v", err)
+	}
+	if err := os.WriteFile(keyPath, keyFile, 0o600); err != nil {
+		t.Fatalf("failed to write key file: %v", err)
+	}
+
+	tests := []struct {
+		desc string
+		env  map[string]string
+	}{
+		{desc: "JSON blob", env: map[string]string{aplos.EnvCredentials: string(blob)}},
+		{desc: "credentials file", env: map[string]string{aplos.EnvCredentialsFile: credsPath}},
+		{desc: "key", env: map[string]string{aplos.EnvClientID: srv.Credentials.ClientID, aplos.EnvKey: string(keyFile)}},
+		{desc: "key file", env: map[string]string{aplos.EnvClientID: srv.Credentials.ClientID, aplos.EnvKeyFile: keyPath}},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			clearCredentialsEnv(t)
+			t.Setenv(aplos.EnvBaseURL, srv.URL)
+			for k, v := range test.env {
+				t.Setenv(k, v)
+			}
+
+			c, err := aplos.NewFromEnv()
+			if err != nil {
+				t.Fatalf("NewFromEnv: %v", err)
+			}
+			if _, err := c.Funds(context.Background()); err != nil {
+				t.Errorf("Funds: %v", err)
+			}
+		})
+	}
+}
+
+func TestNewFromEnvErrors(t *testing.T) {
+	clearCredentialsEnv(t)
+	if _, err := aplos.NewFromEnv(); !errors.Is(err, aplos.ErrNoCredentials) {
+		t.Errorf("NewFromEnv() with no credentials = %v, want ErrNoCredentials", err)
+	}
+
+	t.Setenv(aplos.EnvClientID, "client-id")
+	if _, err := aplos.NewFromEnv(); err == nil {
+		t.Error("NewFromEnv() without a key returned no error, want one")
+	}
+
+	t.Setenv(aplos.EnvCredentials, `{"client_id": "client-id"}`)
+	if _, err := aplos.NewFromEnv(); err == nil {
+		t.Error("NewFromEnv() with credentials without a key returned no error, want one")
+	}
+}
+
+func TestNewFromCredentialsFile(t *testing.T) {
+	srv := aplostest.NewServer()
+	defer srv.Close()
+	srv.Load(aplostest.SmallNonprofit())
+	keyFile, err := srv.Credentials.KeyFile()
+	if err != nil {
+		t.Fatalf("KeyFile: %v", err)
+	}
+	blob, err := json.Marshal(aplos.Credentials{ClientID: srv.Credentials.ClientID, Key: string(keyFile), BaseURL: srv.URL})
+	if err != nil {
+		t.Fatalf("failed to marshal credentials: %v", err)
+	}
+	fp := filepath.Join(t.TempDir(), "credentials.json")
+	if err := os.WriteFile(fp, blob, 0o600); err != nil {
+		t.Fatalf("failed to write credentials file: %v", err)
+	}
+
+	c, err := aplos.NewFromCredentialsFile(fp)
+	if err != nil {
+		t.Fatalf("NewFromCredentialsFile: %v", err)
+	}
+	if _, err := c.Funds(context.Background()); err != nil {
+		t.Errorf("Funds: %v", err)
+	}
+}
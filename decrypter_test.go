@@ -0,0 +1,82 @@
+package aplos_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"io"
+	"testing"
+
+	"github.com/Silicon-Ally/aplos"
+	"github.com/Silicon-Ally/aplos/aplostest"
+)
+
+// remoteKey stands in for a key held by a KMS or HSM, which only exposes its
+// public key and a decrypt operation.
+type remoteKey struct {
+	key *rsa.PrivateKey
+	// pub overrides the public key, if set.
+	pub   crypto.PublicKey
+	calls int
+}
+
+func (k *remoteKey) Public() crypto.PublicKey {
+	if k.pub != nil {
+		return k.pub
+	}
+	return &k.key.PublicKey
+}
+
+func (k *remoteKey) Decrypt(r io.Reader, msg []byte, opts crypto.DecrypterOpts) ([]byte, error) {
+	k.calls++
+	if _, ok := opts.(*rsa.PKCS1v15DecryptOptions); !ok {
+		panic("want PKCS #1 v1.5 decryption")
+	}
+	return k.key.Decrypt(r, msg, opts)
+}
+
+func TestNewWithDecrypter(t *testing.T) {
+	srv := aplostest.NewServer()
+	defer srv.Close()
+	srv.Load(aplostest.SmallNonprofit())
+
+	key := &remoteKey{key: srv.Credentials.Key}
+	c, err := aplos.NewWithDecrypter(srv.Credentials.ClientID, key, aplos.WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("NewWithDecrypter: %v", err)
+	}
+	if _, err := c.Funds(context.Background()); err != nil {
+		t.Errorf("Funds() = %v, want no error", err)
+	}
+	if key.calls != 1 {
+		t.Errorf("Decrypt called %d times, want 1", key.calls)
+	}
+
+	p, err := aplos.NewClientPoolWithDecrypter(srv.Credentials.ClientID, key, aplos.WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClientPoolWithDecrypter: %v", err)
+	}
+	pc, err := p.Client(0)
+	if err != nil {
+		t.Fatalf("Client: %v", err)
+	}
+	if _, err := pc.Funds(context.Background()); err != nil {
+		t.Errorf("pool client Funds() = %v, want no error", err)
+	}
+}
+
+func TestNewWithDecrypterInvalidKey(t *testing.T) {
+	if _, err := aplos.NewWithDecrypter("client-id", nil); err == nil {
+		t.Error("NewWithDecrypter with no key returned no error, want one")
+	}
+	ec, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	if _, err := aplos.NewWithDecrypter("client-id", &remoteKey{pub: ec.Public()}, aplos.WithLazyAuth()); err == nil {
+		t.Error("NewWithDecrypter with an ECDSA key returned no error, want one")
+	}
+}
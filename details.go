@@ -0,0 +1,100 @@
+package aplos
+
+import (
+	"context"
+	"fmt"
+)
+
+// DetailFailure records a transaction whose details couldn't be loaded.
+type DetailFailure struct {
+	ID  int
+	Err error
+}
+
+// PartialResultsError is returned by TransactionDetails with
+// WithPartialResults when some, but not necessarily all, transactions failed to
+// load. It unwraps to the individual failures, so e.g.
+// errors.Is(err, ErrPermissionDenied) reports whether any failed for lack of
+// permission.
+type PartialResultsError struct {
+	// Requested is the number of transactions requested.
+	Requested int
+	// Failures lists the transactions that couldn't be loaded, in the order
+	// they were requested.
+	Failures []DetailFailure
+}
+
+func (e *PartialResultsError) Error() string {
+	return fmt.Sprintf("failed to load %d of %d transactions, first failure was transaction %d: %v", len(e.Failures), e.Requested, e.Failures[0].ID, e.Failures[0].Err)
+}
+
+func (e *PartialResultsError) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, f := range e.Failures {
+		errs[i] = f.Err
+	}
+	return errs
+}
+
+// IDs returns the IDs of the failed transactions, e.g. to retry them later.
+func (e *PartialResultsError) IDs() []int {
+	ids := make([]int, len(e.Failures))
+	for i, f := range e.Failures {
+		ids[i] = f.ID
+	}
+	return ids
+}
+
+type detailOpts struct {
+	partial bool
+}
+
+// WithPartialResults makes TransactionDetails keep going when a transaction
+// fails to load, returning the transactions that did load alongside a
+// *PartialResultsError listing the ones that didn't, rather than aborting a
+// long export because of a single bad record.
+func WithPartialResults() DetailOption {
+	return func(o *detailOpts) {
+		o.partial = true
+	}
+}
+
+type DetailOption func(*detailOpts)
+
+// TransactionDetails loads the full details, including lines, of the
+// transactions with the given IDs, e.g. ones returned by Transactions. By
+// default, it stops at and returns the first error; see WithPartialResults.
+//
+// If ctx is done, fetching stops in either mode and ctx's error is returned,
+// with the transactions loaded so far if WithPartialResults is set.
+func (c *Client) TransactionDetails(ctx context.Context, ids []int, opts ...DetailOption) ([]Transaction, error) {
+	o := &detailOpts{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	txns := make([]Transaction, 0, len(ids))
+	var failures []DetailFailure
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			if !o.partial {
+				return nil, err
+			}
+			return txns, err
+		}
+		txn, err := c.Transaction(ctx, id)
+		if err != nil {
+			if !o.partial {
+				return nil, fmt.Errorf("failed to load transaction %d: %w", id, err)
+			}
+			failures = append(failures, DetailFailure{ID: id, Err: err})
+			continue
+		}
+		txns = append(txns, *txn)
+	}
+
+	if len(failures) > 0 {
+		return txns, &PartialResultsError{Requested: len(ids), Failures: failures}
+	}
+	return txns, nil
+}
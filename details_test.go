@@ -0,0 +1,60 @@
+package aplos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestTransactionDetails(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/transactions/1":
+			fmt.Fprint(w, `{"status": 200, "data": {"transaction": {"id": 1, "memo": "one"}}}`)
+		case "/transactions/2":
+			w.WriteHeader(http.StatusForbidden)
+		case "/transactions/3":
+			fmt.Fprint(w, `{"status": 200, "data": {"transaction": {"id": 3, "memo": "three"}}}`)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	ctx := context.Background()
+	ids := []int{1, 2, 3, 4}
+
+	if txns, err := c.TransactionDetails(ctx, ids); err == nil || txns != nil {
+		t.Errorf("TransactionDetails() = %v, %v, want an error and no transactions", txns, err)
+	}
+
+	txns, err := c.TransactionDetails(ctx, ids, WithPartialResults())
+	want := []Transaction{{ID: 1, Memo: "one"}, {ID: 3, Memo: "three"}}
+	if !reflect.DeepEqual(txns, want) {
+		t.Errorf("TransactionDetails(partial) = %+v, want %+v", txns, want)
+	}
+	var partialErr *PartialResultsError
+	if !errors.As(err, &partialErr) {
+		t.Fatalf("TransactionDetails(partial) error = %v, want a *PartialResultsError", err)
+	}
+	if got, want := partialErr.IDs(), []int{2, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("IDs() = %v, want %v", got, want)
+	}
+	if partialErr.Requested != 4 {
+		t.Errorf("Requested = %d, want 4", partialErr.Requested)
+	}
+	if !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("errors.Is(%v, ErrPermissionDenied) = false, want true", err)
+	}
+
+	if txns, err := c.TransactionDetails(ctx, []int{1, 3}, WithPartialResults()); err != nil || len(txns) != 2 {
+		t.Errorf("TransactionDetails(all succeed) = %v, %v, want 2 transactions and no error", txns, err)
+	}
+
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := c.TransactionDetails(cctx, ids, WithPartialResults()); !errors.Is(err, context.Canceled) {
+		t.Errorf("TransactionDetails(canceled) = %v, want context.Canceled", err)
+	}
+}
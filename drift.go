@@ -0,0 +1,229 @@
+package aplos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Drift describes differences between the fields returned by an API endpoint
+// and the fields this package knows how to decode.
+type Drift struct {
+	// Endpoint is a description of the endpoint that was checked, e.g.
+	// "GET /accounts".
+	Endpoint string
+	// Added lists fields returned by the API that this package doesn't know
+	// about, as dotted paths like "accounts[].account_group.color".
+	Added []string
+	// Removed lists fields this package knows about that weren't present on
+	// any record returned by the API.
+	Removed []string
+}
+
+// SchemaDrift queries a few read-only endpoints and compares the fields in the
+// raw responses against the package's types, returning one Drift for each
+// endpoint where the two disagree. It's meant to be run periodically to learn
+// about Aplos API changes before they silently break decoding.
+//
+// Fields can only be checked if the endpoint returns data, so running this
+// against an empty organization won't report much.
+func (c *Client) SchemaDrift(ctx context.Context) ([]Drift, error) {
+	var out []Drift
+
+	accts, err := c.getRawData(ctx, "/accounts")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load accounts: %w", err)
+	}
+	if d := checkDrift("GET /accounts", "accounts", accts, reflect.TypeOf(Account{}), nil); d != nil {
+		out = append(out, *d)
+	}
+
+	txns, err := c.getRawData(ctx, "/transactions")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load transactions: %w", err)
+	}
+	// Lines are only returned from the single transaction endpoint.
+	if d := checkDrift("GET /transactions", "transactions", txns, reflect.TypeOf(Transaction{}), []string{"transactions[].lines"}); d != nil {
+		out = append(out, *d)
+	}
+
+	id, ok := firstID(txns["transactions"])
+	if !ok {
+		return out, nil
+	}
+	txn, err := c.getRawData(ctx, "/transactions/"+strconv.Itoa(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load transaction %d: %w", id, err)
+	}
+	if d := checkDrift("GET /transactions/{id}", "transaction", txn, reflect.TypeOf(Transaction{}), nil); d != nil {
+		out = append(out, *d)
+	}
+
+	return out, nil
+}
+
+type rawResponse struct {
+	Data map[string]interface{}
+}
+
+func (c *Client) getRawData(ctx context.Context, path string) (map[string]interface{}, error) {
+	var raw rawResponse
+	if err := c.get(ctx, path, nil, &raw); err != nil {
+		return nil, err
+	}
+	return raw.Data, nil
+}
+
+func firstID(v interface{}) (int, bool) {
+	list, ok := v.([]interface{})
+	if !ok || len(list) == 0 {
+		return 0, false
+	}
+	obj, ok := list[0].(map[string]interface{})
+	if !ok {
+		return 0, false
+	}
+	id, ok := obj["id"].(float64)
+	if !ok {
+		return 0, false
+	}
+	return int(id), true
+}
+
+// checkDrift compares data[key], which may be a single object or a list of
+// objects, against the fields of typ. It returns nil if there's no drift.
+func checkDrift(endpoint, key string, data map[string]interface{}, typ reflect.Type, ignore []string) *Drift {
+	path := key
+	var objs []map[string]interface{}
+	switch v := data[key].(type) {
+	case map[string]interface{}:
+		objs = []map[string]interface{}{v}
+	case []interface{}:
+		path += "[]"
+		objs = objects(v)
+	}
+
+	var added, removed []string
+	compareFields(path, typ, objs, &added, &removed)
+	removed = filterOut(removed, ignore)
+	if len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return &Drift{Endpoint: endpoint, Added: added, Removed: removed}
+}
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+type knownField struct {
+	name string
+	typ  reflect.Type
+	// optional fields, marked omitempty, aren't always returned by the API, so
+	// they aren't reported as removed.
+	optional bool
+}
+
+// compareFields records fields present in objs but not on typ in added, and
+// fields on typ that weren't present in any of objs in removed. Field names are
+// matched case-insensitively, like encoding/json does.
+func compareFields(path string, typ reflect.Type, objs []map[string]interface{}, added, removed *[]string) {
+	if len(objs) == 0 {
+		return
+	}
+
+	known := make(map[string]knownField)
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := f.Name
+		tag, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if tag == "-" {
+			continue
+		} else if tag != "" {
+			name = tag
+		}
+		known[strings.ToLower(name)] = knownField{
+			name:     name,
+			typ:      f.Type,
+			optional: strings.Contains(","+opts+",", ",omitempty,"),
+		}
+	}
+
+	seen := make(map[string]bool)
+	nested := make(map[string][]map[string]interface{})
+	for _, obj := range objs {
+		for k, v := range obj {
+			lk := strings.ToLower(k)
+			if _, ok := known[lk]; !ok {
+				if !seen["+"+k] {
+					*added = append(*added, path+"."+k)
+					seen["+"+k] = true
+				}
+				continue
+			}
+			seen[lk] = true
+			switch vv := v.(type) {
+			case map[string]interface{}:
+				nested[lk] = append(nested[lk], vv)
+			case []interface{}:
+				nested[lk] = append(nested[lk], objects(vv)...)
+			}
+		}
+	}
+
+	for lk, f := range known {
+		if !seen[lk] {
+			if f.optional {
+				continue
+			}
+			*removed = append(*removed, path+"."+strings.ToLower(f.name))
+			continue
+		}
+		ft := f.typ
+		suffix := ""
+		for ft.Kind() == reflect.Pointer || ft.Kind() == reflect.Slice {
+			if ft.Kind() == reflect.Slice {
+				suffix = "[]"
+			}
+			ft = ft.Elem()
+		}
+		if ft.Kind() != reflect.Struct || reflect.PointerTo(ft).Implements(unmarshalerType) {
+			continue
+		}
+		compareFields(path+"."+strings.ToLower(f.name)+suffix, ft, nested[lk], added, removed)
+	}
+}
+
+func objects(vs []interface{}) []map[string]interface{} {
+	var out []map[string]interface{}
+	for _, v := range vs {
+		if obj, ok := v.(map[string]interface{}); ok {
+			out = append(out, obj)
+		}
+	}
+	return out
+}
+
+func filterOut(in, remove []string) []string {
+	var out []string
+	for _, s := range in {
+		skip := false
+		for _, r := range remove {
+			if s == r {
+				skip = true
+				break
+			}
+		}
+		if !skip {
+			out = append(out, s)
+		}
+	}
+	return out
+}
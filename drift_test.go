@@ -0,0 +1,65 @@
+package aplos
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestCheckDrift(t *testing.T) {
+	tests := []struct {
+		desc   string
+		key    string
+		data   string
+		typ    reflect.Type
+		ignore []string
+		want   *Drift
+	}{
+		{
+			desc: "no drift",
+			key:  "accounts",
+			data: `{"accounts": [{"account_number": 1000, "name": "Checking", "category": "asset", "account_group": {"id": 1, "name": "Cash", "seq": 1}, "is_enabled": true, "type": "bank", "activity": "none"}]}`,
+			typ:  reflect.TypeOf(Account{}),
+			want: nil,
+		},
+		{
+			desc: "added and removed fields",
+			key:  "accounts",
+			data: `{"accounts": [{"account_number": 1000, "Name": "Checking", "category": "asset", "account_group": {"id": 1, "name": "Cash", "color": "red"}, "is_enabled": true, "type": "bank", "description": "Main account"}]}`,
+			typ:  reflect.TypeOf(Account{}),
+			want: &Drift{
+				Endpoint: "test",
+				Added:    []string{"accounts[].account_group.color", "accounts[].description"},
+				Removed:  []string{"accounts[].account_group.seq", "accounts[].activity"},
+			},
+		},
+		{
+			desc:   "single object with ignored field",
+			key:    "transaction",
+			data:   `{"transaction": {"id": 1, "memo": "", "date": "2020-01-02", "id_number": 0, "created": "2020-01-02T03:04:05.000-0000", "amount": 1, "in_closed_period": false}}`,
+			typ:    reflect.TypeOf(Transaction{}),
+			ignore: []string{"transaction.lines"},
+			want:   nil,
+		},
+		{
+			desc: "empty list",
+			key:  "transactions",
+			data: `{"transactions": []}`,
+			typ:  reflect.TypeOf(Transaction{}),
+			want: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			var data map[string]interface{}
+			if err := json.Unmarshal([]byte(test.data), &data); err != nil {
+				t.Fatalf("failed to unmarshal test data: %v", err)
+			}
+			got := checkDrift("test", test.key, data, test.typ, test.ignore)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("checkDrift() = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package aplos
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// The encrypted keys in testdata were created from key.pem with OpenSSL, e.g.
+// "openssl pkcs8 -topk8 -in key.pem -v2 aes-128-cbc -v2prf hmacWithSHA1".
+const testPassphrase = "correct horse battery staple"
+
+func TestLoadPrivateKeyFromFileWithPassphrase(t *testing.T) {
+	want, err := LoadPrivateKeyFromFile(filepath.Join("testdata", "key.pem"))
+	if err != nil {
+		t.Fatalf("LoadPrivateKeyFromFile: %v", err)
+	}
+
+	tests := []struct {
+		desc string
+		file string
+	}{
+		{desc: "AES-256, HMAC-SHA256", file: "key-aes256.pem"},
+		{desc: "AES-128, HMAC-SHA1", file: "key-aes128-sha1.pem"},
+		{desc: "3DES, base64 DER", file: "key-des3.b64"},
+		{desc: "unencrypted", file: "key.pem"},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			fp := filepath.Join("testdata", test.file)
+			got, err := LoadPrivateKeyFromFileWithPassphrase(fp, []byte(testPassphrase))
+			if err != nil {
+				t.Fatalf("LoadPrivateKeyFromFileWithPassphrase: %v", err)
+			}
+			if !got.Equal(want) {
+				t.Error("loaded key doesn't match")
+			}
+		})
+	}
+}
+
+func TestLoadEncryptedPrivateKeyWrongPassphrase(t *testing.T) {
+	for _, file := range []string{"key-aes256.pem", "key-aes128-sha1.pem"} {
+		dat, err := os.ReadFile(filepath.Join("testdata", file))
+		if err != nil {
+			t.Fatalf("failed to read key: %v", err)
+		}
+		if _, err := LoadEncryptedPrivateKey(dat, []byte("wrong")); !errors.Is(err, ErrIncorrectPassphrase) {
+			t.Errorf("LoadEncryptedPrivateKey(%s) with the wrong passphrase = %v, want ErrIncorrectPassphrase", file, err)
+		}
+	}
+}
+
+func TestLoadEncryptedPrivateKeyIterationCap(t *testing.T) {
+	raw := func(v any) asn1.RawValue {
+		b, err := asn1.Marshal(v)
+		if err != nil {
+			t.Fatalf("failed to marshal %T: %v", v, err)
+		}
+		return asn1.RawValue{FullBytes: b}
+	}
+	// A key that would take billions of iterations to derive.
+	kdf := pbkdf2Params{Salt: []byte("saltsalt"), IterationCount: 1<<31 - 1}
+	params := pbes2Params{
+		KeyDerivationFunc: pkix.AlgorithmIdentifier{Algorithm: oidPBKDF2, Parameters: raw(kdf)},
+		EncryptionScheme:  pkix.AlgorithmIdentifier{Algorithm: oidAES128CBC, Parameters: raw(make([]byte, 16))},
+	}
+	der, err := asn1.Marshal(encryptedPrivateKeyInfo{
+		Algo:          pkix.AlgorithmIdentifier{Algorithm: oidPBES2, Parameters: raw(params)},
+		EncryptedData: make([]byte, 32),
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	if _, err := LoadEncryptedPrivateKey(der, []byte(testPassphrase)); err == nil || errors.Is(err, ErrIncorrectPassphrase) {
+		t.Errorf("LoadEncryptedPrivateKey with %d iterations = %v, want an unsupported iteration count error", kdf.IterationCount, err)
+	}
+}
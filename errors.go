@@ -0,0 +1,224 @@
+package aplos
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ErrDateRangeRequired is returned when listing transactions without a start
+// date on a client created with WithRequireDateRange.
+var ErrDateRangeRequired = errors.New("a date range is required when listing transactions")
+
+// ErrPermissionDenied matches, via errors.Is, errors returned when the API key
+// isn't permitted to access the requested resource, e.g. a fund or account
+// restricted to other users. The API reports these with a 403, rather than
+// returning empty results.
+var ErrPermissionDenied = errors.New("permission denied")
+
+// ErrNotFound matches, via errors.Is, errors returned when the requested
+// resource doesn't exist, e.g. fetching a transaction by an ID that was never
+// assigned or has since been deleted.
+var ErrNotFound = errors.New("not found")
+
+// ErrUnauthorized matches, via errors.Is, errors returned when the API rejects
+// the client's credentials, either during the authentication handshake or
+// because the access token was rejected.
+var ErrUnauthorized = errors.New("unauthorized")
+
+// ClosedPeriodError is returned when modifying or deleting a transaction fails
+// because the transaction is in a closed accounting period. Corrections to
+// these transactions need to be made with a new entry in an open period, or
+// after reopening the period in Aplos.
+type ClosedPeriodError struct {
+	TransactionID int
+	// Err is the underlying error, if the API rejected the request.
+	Err error
+}
+
+func (e *ClosedPeriodError) Error() string {
+	return fmt.Sprintf("transaction %d is in a closed period", e.TransactionID)
+}
+
+func (e *ClosedPeriodError) Unwrap() error {
+	return e.Err
+}
+
+// AccountExistsError is returned when creating or renumbering an account fails
+// because an account with the same number already exists.
+type AccountExistsError struct {
+	AccountNumber int
+	// Err is the underlying error from the API.
+	Err error
+}
+
+func (e *AccountExistsError) Error() string {
+	return fmt.Sprintf("account %d already exists", e.AccountNumber)
+}
+
+func (e *AccountExistsError) Unwrap() error {
+	return e.Err
+}
+
+// defaultRetryAfter is the suggested backoff for retryable errors when the API
+// doesn't give us a Retry-After header.
+const defaultRetryAfter = time.Second
+
+// APIError is returned when the Aplos API responds with a non-2xx status code.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Path is the URL path of the request that failed.
+	Path string
+	// Status is the "status" field of the response body, which is usually the
+	// same as StatusCode. It's zero if the body wasn't a JSON response.
+	Status int
+	// Exception is the error details from the response body, if any.
+	Exception *Exception
+
+	// retryAfter is the parsed value of the Retry-After header, if hasRetryAfter
+	// is true.
+	retryAfter    time.Duration
+	hasRetryAfter bool
+}
+
+// Exception describes an error, as reported in the "exception" field of an
+// API response.
+type Exception struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("API returned status %d (%s)", e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Path != "" {
+		msg += " for " + e.Path
+	}
+	if e.Exception != nil && e.Exception.Message != "" {
+		msg += ": " + e.Exception.Message
+	}
+	return msg
+}
+
+// Is allows matching an *APIError against the sentinel errors in this package
+// with errors.Is.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrPermissionDenied:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	default:
+		return false
+	}
+}
+
+// Retryable reports whether the request that produced this error may succeed
+// if sent again, i.e. because we were rate limited or the server had a
+// transient failure.
+func (e *APIError) Retryable() bool {
+	switch e.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// RetryAfter returns how long the caller should wait before retrying the
+// request, and false if the request shouldn't be retried at all. The duration
+// comes from the response's Retry-After header when present, otherwise a
+// default suggestion is returned.
+func (e *APIError) RetryAfter() (time.Duration, bool) {
+	if !e.Retryable() {
+		return 0, false
+	}
+	if e.hasRetryAfter {
+		return e.retryAfter, true
+	}
+	return defaultRetryAfter, true
+}
+
+// RetryAfter inspects an error returned from a Client method and reports
+// whether the operation is worth retrying and, if so, after how long. In
+// addition to retryable API errors, network timeouts are considered retryable.
+func RetryAfter(err error) (time.Duration, bool) {
+	var r interface {
+		RetryAfter() (time.Duration, bool)
+	}
+	if errors.As(err, &r) {
+		return r.RetryAfter()
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return defaultRetryAfter, true
+	}
+	return 0, false
+}
+
+// maxErrorBodySize is the most we'll read of an error response looking for
+// error details.
+const maxErrorBodySize = 64 << 10
+
+// errorResponse is the body of an API error response.
+type errorResponse struct {
+	Status    int        `json:"status"`
+	Exception *Exception `json:"exception"`
+}
+
+// checkResponse returns an *APIError if the response doesn't have a 2xx status
+// code, populated with the error details from the response body when it has
+// any.
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+	if resp.Request != nil && resp.Request.URL != nil {
+		apiErr.Path = resp.Request.URL.Path
+	}
+	apiErr.retryAfter, apiErr.hasRetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+
+	// The body is best-effort: errors from proxies and load balancers often
+	// aren't JSON, and the status code is enough to go on.
+	var body errorResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxErrorBodySize)).Decode(&body); err == nil {
+		apiErr.Status = body.Status
+		apiErr.Exception = body.Exception
+	}
+	return apiErr
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a
+// number of seconds or an HTTP date.
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	t, err := http.ParseTime(v)
+	if err != nil {
+		return 0, false
+	}
+	d := t.Sub(now)
+	if d < 0 {
+		d = 0
+	}
+	return d, true
+}
@@ -0,0 +1,161 @@
+package aplos
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2023, time.May, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		in     string
+		want   time.Duration
+		wantOK bool
+	}{
+		{in: "", wantOK: false},
+		{in: "120", want: 2 * time.Minute, wantOK: true},
+		{in: "-1", wantOK: false},
+		{in: "Mon, 01 May 2023 12:00:30 GMT", want: 30 * time.Second, wantOK: true},
+		{in: "Mon, 01 May 2023 11:00:00 GMT", want: 0, wantOK: true},
+		{in: "soon", wantOK: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			got, ok := parseRetryAfter(test.in, now)
+			if got != test.want || ok != test.wantOK {
+				t.Errorf("parseRetryAfter(%q) = %v, %t, want %v, %t", test.in, got, ok, test.want, test.wantOK)
+			}
+		})
+	}
+}
+
+func TestAPIErrorRetryAfter(t *testing.T) {
+	tests := []struct {
+		desc       string
+		status     int
+		retryAfter string
+		want       time.Duration
+		wantOK     bool
+	}{
+		{
+			desc:       "rate limited with header",
+			status:     http.StatusTooManyRequests,
+			retryAfter: "5",
+			want:       5 * time.Second,
+			wantOK:     true,
+		},
+		{
+			desc:   "server error without header",
+			status: http.StatusServiceUnavailable,
+			want:   defaultRetryAfter,
+			wantOK: true,
+		},
+		{
+			desc:   "not found",
+			status: http.StatusNotFound,
+			wantOK: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if test.retryAfter != "" {
+					w.Header().Set("Retry-After", test.retryAfter)
+				}
+				w.WriteHeader(test.status)
+			}))
+			_, err := c.Transaction(context.Background(), 123)
+			if err == nil {
+				t.Fatal("Transaction() returned no error")
+			}
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("Transaction() returned %T, want an *APIError", err)
+			}
+			if apiErr.StatusCode != test.status {
+				t.Errorf("StatusCode = %d, want %d", apiErr.StatusCode, test.status)
+			}
+
+			got, ok := RetryAfter(err)
+			if got != test.want || ok != test.wantOK {
+				t.Errorf("RetryAfter() = %v, %t, want %v, %t", got, ok, test.want, test.wantOK)
+			}
+		})
+	}
+}
+
+func TestAPIErrorDetails(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"version":"0.0.1","status":404,"exception":{"code":404,"message":"Transaction not found"}}`))
+	}))
+
+	_, err := c.Transaction(context.Background(), 123)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Transaction() returned %v, want an *APIError", err)
+	}
+	want := &APIError{
+		StatusCode: http.StatusNotFound,
+		Path:       "/transactions/123",
+		Status:     http.StatusNotFound,
+		Exception:  &Exception{Code: http.StatusNotFound, Message: "Transaction not found"},
+	}
+	if !reflect.DeepEqual(apiErr, want) {
+		t.Errorf("error = %+v, want %+v", apiErr, want)
+	}
+	if got, want := apiErr.Error(), "API returned status 404 (Not Found) for /transactions/123: Transaction not found"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestAPIErrorNonJSONBody(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "<html>Bad Gateway</html>", http.StatusBadGateway)
+	}))
+
+	_, err := c.Transaction(context.Background(), 123)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Transaction() returned %v, want an *APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusBadGateway || apiErr.Status != 0 || apiErr.Exception != nil {
+		t.Errorf("error = %+v, want a 502 without details", apiErr)
+	}
+}
+
+func TestAPIErrorIs(t *testing.T) {
+	sentinels := []error{ErrPermissionDenied, ErrNotFound, ErrUnauthorized}
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{status: http.StatusForbidden, want: ErrPermissionDenied},
+		{status: http.StatusNotFound, want: ErrNotFound},
+		{status: http.StatusUnauthorized, want: ErrUnauthorized},
+		{status: http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		t.Run(http.StatusText(test.status), func(t *testing.T) {
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(test.status)
+			}))
+
+			_, err := c.Transaction(context.Background(), 123)
+			for _, s := range sentinels {
+				if got, want := errors.Is(err, s), s == test.want; got != want {
+					t.Errorf("errors.Is(%v, %v) = %t, want %t", err, s, got, want)
+				}
+			}
+		})
+	}
+}
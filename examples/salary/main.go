@@ -75,7 +75,7 @@ func run(args []string) error {
 	tick := time.NewTicker(250 * time.Millisecond)
 	defer tick.Stop()
 
-	var total float64
+	var total aplos.Amount
 	for _, t := range txns {
 		txn, err := c.Transaction(ctx, t.ID)
 		if err != nil {
@@ -86,7 +86,7 @@ func run(args []string) error {
 			return fmt.Errorf("transaction %d had no salary component", t.ID)
 		}
 		<-tick.C
-		fmt.Printf("Salary for transaction %d: %f\n", t.ID, v)
+		fmt.Printf("Salary for transaction %d: %s\n", t.ID, v)
 		total += v
 	}
 
@@ -95,9 +95,9 @@ func run(args []string) error {
 	return nil
 }
 
-func sumLinesByAccount(lines []aplos.TransactionLine, acctNumber int) (float64, bool) {
+func sumLinesByAccount(lines []aplos.TransactionLine, acctNumber int) (aplos.Amount, bool) {
 	found := false
-	var total float64
+	var total aplos.Amount
 	for _, l := range lines {
 		if l.Account.AccountNumber == acctNumber {
 			total += l.Amount
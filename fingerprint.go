@@ -0,0 +1,80 @@
+package aplos
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// batchToken matches the batch tokens added to memos by importer.BatchToken.
+var batchToken = regexp.MustCompile(`\[import:[^\]\s]*\]`)
+
+// Fingerprint returns a stable identifier for the content of a transaction, for
+// detecting duplicates, diffing two copies of a ledger, or making imports
+// idempotent. Two transactions have the same fingerprint if they record the
+// same entry, regardless of their IDs, when they were created, or the order of
+// their lines.
+//
+// The fingerprint is the lowercase hex SHA-256 of the following text, with
+// each line terminated by "\n", so that other systems can compute it too:
+//
+//	aplos-fingerprint-v2
+//	date:<YYYY-MM-DD>
+//	ref:<IDNumber, 0 if unset>
+//	contact:<Contact.ID, 0 if unset>
+//	memo:<Memo, without import batch tokens, lowercased, with runs of whitespace collapsed to one space and trimmed>
+//	line:<account number>:<fund ID>:<amount in cents>
+//
+// Line amounts are summed per account and fund, and "line:" entries are sorted
+// by account number and then fund ID. Transactions from Transactions don't
+// include lines, so if there are none, a single "amount:<Amount in cents>"
+// entry is used instead. Compare fingerprints of transactions loaded the same
+// way.
+//
+// Import batch tokens, like "[import:20230507-9f86d081]" as added by the
+// importer package, are removed from the memo, so the same entry imported in
+// different batches has the same fingerprint. Version 1 fingerprints kept the
+// tokens, and don't match the current version's.
+func Fingerprint(txn Transaction) string {
+	var b strings.Builder
+	b.WriteString("aplos-fingerprint-v2\n")
+	b.WriteString("date:" + txn.Date.String() + "\n")
+	b.WriteString("ref:" + txn.IDNumber.String() + "\n")
+	contactID := 0
+	if txn.Contact != nil {
+		contactID = txn.Contact.ID
+	}
+	b.WriteString("contact:" + strconv.Itoa(contactID) + "\n")
+	memo := batchToken.ReplaceAllString(txn.Memo, " ")
+	b.WriteString("memo:" + strings.Join(strings.Fields(strings.ToLower(memo)), " ") + "\n")
+
+	if len(txn.Lines) == 0 {
+		b.WriteString("amount:" + strconv.FormatInt(txn.Amount.Cents(), 10) + "\n")
+	} else {
+		type key struct{ account, fund int }
+		sums := make(map[key]int64)
+		var keys []key
+		for _, l := range txn.Lines {
+			k := key{l.Account.AccountNumber, l.Fund.ID}
+			if _, ok := sums[k]; !ok {
+				keys = append(keys, k)
+			}
+			sums[k] += l.Amount.Cents()
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if keys[i].account != keys[j].account {
+				return keys[i].account < keys[j].account
+			}
+			return keys[i].fund < keys[j].fund
+		})
+		for _, k := range keys {
+			b.WriteString("line:" + strconv.Itoa(k.account) + ":" + strconv.Itoa(k.fund) + ":" + strconv.FormatInt(sums[k], 10) + "\n")
+		}
+	}
+
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,70 @@
+package aplos
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFingerprint(t *testing.T) {
+	base := Transaction{
+		ID:       1,
+		Date:     d(2023, time.May, 1),
+		Memo:     "Office  supplies ",
+		IDNumber: 1042,
+		Contact:  &Contact{ID: 9},
+		Lines: []TransactionLine{
+			{ID: 1, Amount: 40_10, Account: Account{AccountNumber: 5000}, Fund: Fund{ID: 1}},
+			{ID: 2, Amount: 10_00, Account: Account{AccountNumber: 5000}, Fund: Fund{ID: 1}},
+			{ID: 3, Amount: -50_10, Account: Account{AccountNumber: 1000}, Fund: Fund{ID: 1}},
+		},
+	}
+	// A copy recorded separately, with different IDs and line order, and the
+	// expense lines combined.
+	same := Transaction{
+		ID:       2,
+		Date:     d(2023, time.May, 1),
+		Memo:     "office supplies",
+		Created:  Time{time.Now()},
+		IDNumber: 1042,
+		Contact:  &Contact{ID: 9, CompanyName: "Staples"},
+		Lines: []TransactionLine{
+			{ID: 7, Amount: -50_10, Account: Account{AccountNumber: 1000}, Fund: Fund{ID: 1}},
+			{ID: 8, Amount: 50_10, Account: Account{AccountNumber: 5000, Name: "Supplies"}, Fund: Fund{ID: 1}},
+		},
+	}
+	if got, want := Fingerprint(same), Fingerprint(base); got != want {
+		t.Errorf("Fingerprint(same) = %s, want %s", got, want)
+	}
+
+	imported := base
+	imported.Memo = "Office supplies [import:20230507-9f86d081]"
+	if got, want := Fingerprint(imported), Fingerprint(base); got != want {
+		t.Errorf("Fingerprint(imported) = %s, want %s, ignoring the batch token", got, want)
+	}
+
+	// Fixed so that changes to the documented format are caught.
+	if got, want := Fingerprint(base), "e72cdd8edddbb42b14fbda1c50dd3f89ff5c5c65f5db57ad6b0b870632aae504"; got != want {
+		t.Errorf("Fingerprint(base) = %s, want %s", got, want)
+	}
+
+	tests := []struct {
+		desc   string
+		modify func(*Transaction)
+	}{
+		{"date", func(t *Transaction) { t.Date.Day = 2 }},
+		{"memo", func(t *Transaction) { t.Memo = "Office supplies refund" }},
+		{"ref number", func(t *Transaction) { t.IDNumber = 1043 }},
+		{"contact", func(t *Transaction) { t.Contact = nil }},
+		{"amount", func(t *Transaction) { t.Lines[0].Amount = 40_11; t.Lines[2].Amount = -50_11 }},
+		{"fund", func(t *Transaction) { t.Lines[1].Fund.ID = 2 }},
+		{"no lines", func(t *Transaction) { t.Lines = nil }},
+	}
+	for _, test := range tests {
+		txn := base
+		txn.Lines = append([]TransactionLine(nil), base.Lines...)
+		test.modify(&txn)
+		if Fingerprint(txn) == Fingerprint(base) {
+			t.Errorf("changing the %s didn't change the fingerprint", test.desc)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package aplos
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+type Fund struct {
+	ID   int
+	Name string
+
+	// Populated in Funds and Fund
+	Description string
+	IsEnabled   bool `json:"is_enabled"`
+
+	// IsAccessible is false if the API key can see that the fund exists but
+	// isn't permitted to access its details or activity. It's nil if the API
+	// didn't say, see Accessible.
+	IsAccessible *bool `json:"is_accessible,omitempty"`
+}
+
+// Accessible reports whether the API key can access the fund's details and
+// activity. Funds are assumed to be accessible unless the API says otherwise.
+func (f Fund) Accessible() bool {
+	return f.IsAccessible == nil || *f.IsAccessible
+}
+
+type getFundResponse struct {
+	Version string
+	Status  int
+	Data    getFundResponseData
+}
+
+type getFundResponseData struct {
+	Fund Fund
+}
+
+// Fund returns the fund with the given ID.
+func (c *Client) Fund(ctx context.Context, id int) (*Fund, error) {
+	var gResp getFundResponse
+	if err := c.get(ctx, "/funds/"+strconv.Itoa(id), nil, &gResp); err != nil {
+		return nil, fmt.Errorf("failed to get fund: %w", err)
+	}
+
+	return &gResp.Data.Fund, nil
+}
+
+type listFundsResponse struct {
+	Version string
+	Status  int
+	listPage
+	Data listFundsResponseData
+}
+
+type listFundsResponseData struct {
+	Funds []Fund
+}
+
+type listFundsOpts struct {
+	name    *string
+	enabled *bool
+}
+
+// WithFundName filters funds to those whose name matches the given string.
+func WithFundName(name string) ListFundOption {
+	return func(o *listFundsOpts) {
+		o.name = &name
+	}
+}
+
+// WithFundEnabled filters funds to those that are enabled, or disabled if
+// enabled is false.
+func WithFundEnabled(enabled bool) ListFundOption {
+	return func(o *listFundsOpts) {
+		o.enabled = &enabled
+	}
+}
+
+type ListFundOption func(*listFundsOpts)
+
+// Funds returns a list of funds satisfying the given options. All pages of
+// results are loaded.
+func (c *Client) Funds(ctx context.Context, opts ...ListFundOption) ([]Fund, error) {
+	o := &listFundsOpts{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	q := url.Values{}
+	if o.name != nil {
+		q.Add("f_name", *o.name)
+	}
+	if o.enabled != nil {
+		q.Add("f_enabled", strconv.FormatBool(*o.enabled))
+	}
+
+	var funds []Fund
+	err := forEachPage(ctx, c, c.url("/funds", q), func(r *listFundsResponse) bool {
+		if funds == nil {
+			funds = make([]Fund, 0, r.Meta.RecordCount)
+		}
+		funds = append(funds, r.Data.Funds...)
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list funds: %w", err)
+	}
+	return funds, nil
+}
+
+// FundBalance summarizes the activity in a fund over a period.
+type FundBalance struct {
+	Fund            Fund
+	StartingBalance Amount `json:"starting_balance"`
+	Income          Amount
+	Expense         Amount
+	EndingBalance   Amount `json:"ending_balance"`
+}
+
+type fundBalancesResponse struct {
+	Version string
+	Status  int
+	Data    fundBalancesResponseData
+}
+
+type fundBalancesResponseData struct {
+	FundBalances []FundBalance `json:"fund_balances"`
+}
+
+// FundBalances returns the starting balance, income, expense, and ending
+// balance of each fund for the period from start to end, inclusive, as computed
+// by Aplos.
+func (c *Client) FundBalances(ctx context.Context, start, end Date) ([]FundBalance, error) {
+	q := url.Values{}
+	q.Add("f_rangestart", start.String())
+	q.Add("f_rangeend", end.String())
+
+	var fResp fundBalancesResponse
+	if err := c.get(ctx, "/reports/fundbalances", q, &fResp); err != nil {
+		return nil, fmt.Errorf("failed to get fund balances: %w", err)
+	}
+
+	return fResp.Data.FundBalances, nil
+}
@@ -0,0 +1,116 @@
+package aplos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFunds(t *testing.T) {
+	var gotQuery string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/funds" {
+			t.Errorf("request path = %q, want /funds", r.URL.Path)
+		}
+		gotQuery = r.URL.RawQuery
+		fmt.Fprint(w, `{"status": 200, "data": {"funds": [{"id": 1, "name": "General", "description": "Unrestricted", "is_enabled": true}]}}`)
+	}))
+
+	got, err := c.Funds(context.Background(), WithFundName("General"), WithFundEnabled(true))
+	if err != nil {
+		t.Fatalf("Funds: %v", err)
+	}
+
+	if want := "f_enabled=true&f_name=General"; gotQuery != want {
+		t.Errorf("query = %q, want %q", gotQuery, want)
+	}
+	want := []Fund{{ID: 1, Name: "General", Description: "Unrestricted", IsEnabled: true}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Funds() = %+v, want %+v", got, want)
+	}
+}
+
+func TestFund(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/funds/3" {
+			t.Errorf("request path = %q, want /funds/3", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"status": 200, "data": {"fund": {"id": 3, "name": "Building", "is_enabled": false}}}`)
+	}))
+
+	got, err := c.Fund(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("Fund: %v", err)
+	}
+	want := &Fund{ID: 3, Name: "Building"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Fund() = %+v, want %+v", got, want)
+	}
+}
+
+func TestFundBalances(t *testing.T) {
+	var gotQuery string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/reports/fundbalances" {
+			t.Errorf("request path = %q, want /reports/fundbalances", r.URL.Path)
+		}
+		gotQuery = r.URL.RawQuery
+		fmt.Fprint(w, `{"status": 200, "data": {"fund_balances": [{"fund": {"id": 1, "name": "General"}, "starting_balance": 100, "income": 50.5, "expense": 20.25, "ending_balance": 130.25}]}}`)
+	}))
+
+	got, err := c.FundBalances(context.Background(), d(2023, time.January, 1), d(2023, time.March, 31))
+	if err != nil {
+		t.Fatalf("FundBalances: %v", err)
+	}
+
+	if want := "f_rangeend=2023-03-31&f_rangestart=2023-01-01"; gotQuery != want {
+		t.Errorf("query = %q, want %q", gotQuery, want)
+	}
+	want := []FundBalance{{
+		Fund:            Fund{ID: 1, Name: "General"},
+		StartingBalance: 100_00,
+		Income:          50_50,
+		Expense:         20_25,
+		EndingBalance:   130_25,
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FundBalances() = %+v, want %+v", got, want)
+	}
+}
+
+func TestFundAccessible(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/funds":
+			fmt.Fprint(w, `{"status": 200, "data": {"funds": [
+				{"id": 1, "name": "General"},
+				{"id": 2, "name": "Endowment", "is_accessible": false},
+				{"id": 3, "name": "Building", "is_accessible": true}
+			]}}`)
+		case "/funds/2":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			t.Errorf("unexpected request path %q", r.URL.Path)
+		}
+	}))
+
+	funds, err := c.Funds(context.Background())
+	if err != nil {
+		t.Fatalf("Funds: %v", err)
+	}
+	var got []bool
+	for _, f := range funds {
+		got = append(got, f.Accessible())
+	}
+	if want := []bool{true, false, true}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Accessible() = %v, want %v", got, want)
+	}
+
+	if _, err := c.Fund(context.Background(), 2); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("Fund() = %v, want ErrPermissionDenied", err)
+	}
+}
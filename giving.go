@@ -0,0 +1,247 @@
+package aplos
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+)
+
+// YearRange is an inclusive range of calendar years.
+type YearRange struct {
+	First, Last int
+}
+
+// GivingHistory summarizes a contact's contributions by year and purpose, e.g.
+// for a donor statement or a development-team lookup.
+type GivingHistory struct {
+	ContactID int
+	// Household lists the contacts whose gifts are credited to the contact: the
+	// contact itself and, with WithAttribution, the rest of its household.
+	Household []int
+	// Years has one entry per year in the requested range, in order, including
+	// years without any giving.
+	Years []GivingYear
+	// Total is the sum of the household's contributions across all years.
+	Total Amount
+	// Count is the number of contributions across all years.
+	Count int
+	// SoftTotal and SoftCount are for the contributions soft-credited to the
+	// household across all years, see Attribution.
+	SoftTotal Amount
+	SoftCount int
+}
+
+// GivingYear is a contact's giving in a single calendar year.
+type GivingYear struct {
+	Year int
+	// Purposes has the contact's giving to each purpose in the year, ordered by
+	// purpose ID. Contributions without a purpose are under a zero Purpose.
+	Purposes  []PurposeGiving
+	Total     Amount
+	Count     int
+	SoftTotal Amount
+	SoftCount int
+}
+
+// PurposeGiving is a contact's giving to a single purpose.
+type PurposeGiving struct {
+	Purpose   Purpose
+	Total     Amount
+	Count     int
+	SoftTotal Amount
+	SoftCount int
+}
+
+// Attribution describes how gifts are credited beyond the contact who made
+// them. Aplos doesn't record household links or soft credits, so these come
+// from the organization's own records, e.g. a development office's donor
+// database.
+type Attribution struct {
+	// Households lists groups of contact IDs, like spouses, whose gifts are
+	// credited to each other. A contact should be in at most one household.
+	Households [][]int
+	// SoftCredits maps contribution IDs to the contacts soft-credited with
+	// them, e.g. the board member who solicited a gift, or the advisor of a
+	// donor-advised fund. Soft credits are totaled separately from the
+	// household's own gifts, and never count a gift twice.
+	SoftCredits map[int][]int
+}
+
+// household returns the IDs of the contacts in the given contact's household,
+// starting with the contact itself.
+func (a *Attribution) household(contactID int) []int {
+	ids := []int{contactID}
+	if a == nil {
+		return ids
+	}
+	for _, h := range a.Households {
+		if !slices.Contains(h, contactID) {
+			continue
+		}
+		for _, id := range h {
+			if !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+// softCredited returns the IDs of the contributions soft-credited to any of
+// the given contacts, in ascending order.
+func (a *Attribution) softCredited(contactIDs []int) []int {
+	if a == nil {
+		return nil
+	}
+	var ids []int
+	for id, credited := range a.SoftCredits {
+		for _, c := range credited {
+			if slices.Contains(contactIDs, c) {
+				ids = append(ids, id)
+				break
+			}
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+type givingOpts struct {
+	attribution *Attribution
+}
+
+// WithAttribution credits the contact's household with its members' gifts,
+// and totals the gifts soft-credited to the household, as described by a.
+func WithAttribution(a *Attribution) GivingOption {
+	return func(o *givingOpts) {
+		o.attribution = a
+	}
+}
+
+type GivingOption func(*givingOpts)
+
+// GivingHistory loads the contributions from the contact with the given ID in
+// the given years, and summarizes them by year and purpose.
+//
+// With WithAttribution, contributions are loaded for each member of the
+// contact's household, and soft-credited contributions are loaded one at a
+// time by ID.
+func (c *Client) GivingHistory(ctx context.Context, contactID int, years YearRange, opts ...GivingOption) (*GivingHistory, error) {
+	if years.Last < years.First {
+		return nil, fmt.Errorf("invalid year range %d-%d", years.First, years.Last)
+	}
+	o := &givingOpts{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	household := o.attribution.household(contactID)
+	var gifts []Contribution
+	for _, id := range household {
+		contribs, err := c.Contributions(ctx,
+			WithContributionContact(id),
+			WithContributionRangeStart(years.First, time.January, 1),
+			WithContributionRangeEnd(years.Last, time.December, 31),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load giving history for contact %d: %w", id, err)
+		}
+		gifts = append(gifts, contribs...)
+	}
+
+	var soft []Contribution
+	for _, id := range o.attribution.softCredited(household) {
+		if slices.ContainsFunc(gifts, func(g Contribution) bool { return g.ID == id }) {
+			continue
+		}
+		contrib, err := c.Contribution(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load soft-credited contribution %d: %w", id, err)
+		}
+		soft = append(soft, *contrib)
+	}
+
+	h := newGivingHistory(gifts, soft, years)
+	h.ContactID = contactID
+	h.Household = household
+	return h, nil
+}
+
+// newGivingHistory summarizes gifts, and soft-credited contributions that
+// aren't also in gifts, ignoring any outside of years.
+func newGivingHistory(gifts, soft []Contribution, years YearRange) *GivingHistory {
+	type key struct{ year, purpose int }
+	type sums struct {
+		total, softTotal Amount
+		count, softCount int
+	}
+	byKey := make(map[key]*sums)
+	purposes := make(map[int]Purpose)
+	add := func(contrib Contribution) *sums {
+		if contrib.Date.Year < years.First || contrib.Date.Year > years.Last {
+			return nil
+		}
+		k := key{contrib.Date.Year, contrib.Purpose.ID}
+		s, ok := byKey[k]
+		if !ok {
+			s = &sums{}
+			byKey[k] = s
+		}
+		purposes[contrib.Purpose.ID] = contrib.Purpose
+		return s
+	}
+
+	hard := make(map[int]bool)
+	for _, contrib := range gifts {
+		if contrib.ID != 0 && hard[contrib.ID] {
+			continue
+		}
+		hard[contrib.ID] = true
+		if s := add(contrib); s != nil {
+			s.total += contrib.Amount
+			s.count++
+		}
+	}
+	for _, contrib := range soft {
+		if hard[contrib.ID] {
+			continue
+		}
+		if s := add(contrib); s != nil {
+			s.softTotal += contrib.Amount
+			s.softCount++
+		}
+	}
+
+	h := &GivingHistory{}
+	for y := years.First; y <= years.Last; y++ {
+		gy := GivingYear{Year: y}
+		for id, p := range purposes {
+			s, ok := byKey[key{y, id}]
+			if !ok {
+				continue
+			}
+			gy.Purposes = append(gy.Purposes, PurposeGiving{
+				Purpose:   p,
+				Total:     s.total,
+				Count:     s.count,
+				SoftTotal: s.softTotal,
+				SoftCount: s.softCount,
+			})
+			gy.Total += s.total
+			gy.SoftTotal += s.softTotal
+			gy.Count += s.count
+			gy.SoftCount += s.softCount
+		}
+		sort.Slice(gy.Purposes, func(i, j int) bool {
+			return gy.Purposes[i].Purpose.ID < gy.Purposes[j].Purpose.ID
+		})
+		h.Total += gy.Total
+		h.SoftTotal += gy.SoftTotal
+		h.Count += gy.Count
+		h.SoftCount += gy.SoftCount
+		h.Years = append(h.Years, gy)
+	}
+	return h
+}
@@ -0,0 +1,125 @@
+package aplos
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGivingHistory(t *testing.T) {
+	var gotQuery string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.RawQuery
+		fmt.Fprint(w, `{"status": 200, "data": {"contributions": [
+			{"id": 1, "date": "2022-03-01", "amount": 100.10, "purpose": {"id": 3, "name": "Annual Fund"}},
+			{"id": 2, "date": "2022-06-01", "amount": 50.20, "purpose": {"id": 3, "name": "Annual Fund"}},
+			{"id": 3, "date": "2022-12-24", "amount": 25, "purpose": {"id": 1, "name": "Building"}},
+			{"id": 4, "date": "2024-01-15", "amount": 0.1, "purpose": {"id": 3, "name": "Annual Fund"}},
+			{"id": 5, "date": "2024-02-15", "amount": 0.2, "purpose": {"id": 3, "name": "Annual Fund"}}
+		]}}`)
+	}))
+
+	got, err := c.GivingHistory(context.Background(), 7, YearRange{First: 2022, Last: 2024})
+	if err != nil {
+		t.Fatalf("GivingHistory: %v", err)
+	}
+
+	if want := "f_contact=7&f_rangeend=2024-12-31&f_rangestart=2022-01-01"; gotQuery != want {
+		t.Errorf("query = %q, want %q", gotQuery, want)
+	}
+	annual := Purpose{ID: 3, Name: "Annual Fund"}
+	want := &GivingHistory{
+		ContactID: 7,
+		Household: []int{7},
+		Years: []GivingYear{
+			{
+				Year: 2022,
+				Purposes: []PurposeGiving{
+					{Purpose: Purpose{ID: 1, Name: "Building"}, Total: 25_00, Count: 1},
+					{Purpose: annual, Total: 150_30, Count: 2},
+				},
+				Total: 175_30,
+				Count: 3,
+			},
+			{Year: 2023},
+			{Year: 2024, Purposes: []PurposeGiving{{Purpose: annual, Total: 30, Count: 2}}, Total: 30, Count: 2},
+		},
+		Total: 175_60,
+		Count: 5,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GivingHistory() = %+v, want %+v", got, want)
+	}
+}
+
+func TestGivingHistoryInvalidRange(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request")
+	}))
+	if _, err := c.GivingHistory(context.Background(), 7, YearRange{First: 2024, Last: 2022}); err == nil {
+		t.Error("GivingHistory with an inverted range returned no error, want one")
+	}
+}
+
+func TestGivingHistoryAttribution(t *testing.T) {
+	var gotContacts []string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/contributions":
+			contact := r.URL.Query().Get("f_contact")
+			gotContacts = append(gotContacts, contact)
+			switch contact {
+			case "7":
+				fmt.Fprint(w, `{"status": 200, "data": {"contributions": [{"id": 1, "date": "2023-03-01", "amount": 100, "contact": {"id": 7}, "purpose": {"id": 3}}]}}`)
+			case "8":
+				fmt.Fprint(w, `{"status": 200, "data": {"contributions": [{"id": 2, "date": "2023-04-01", "amount": 40, "contact": {"id": 8}, "purpose": {"id": 3}}]}}`)
+			}
+		case "/contributions/5":
+			fmt.Fprint(w, `{"status": 200, "data": {"contribution": {"id": 5, "date": "2023-05-01", "amount": 1000, "contact": {"id": 20}, "purpose": {"id": 3}}}}`)
+		default:
+			t.Errorf("unexpected request for %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+
+	a := &Attribution{
+		Households: [][]int{{8, 7}, {9, 10}},
+		SoftCredits: map[int][]int{
+			// Already credited to the household, so not counted again.
+			2: {7},
+			// Solicited by contact 8.
+			5: {8, 12},
+			// Another household's soft credit.
+			6: {9},
+		},
+	}
+	got, err := c.GivingHistory(context.Background(), 7, YearRange{First: 2023, Last: 2023}, WithAttribution(a))
+	if err != nil {
+		t.Fatalf("GivingHistory: %v", err)
+	}
+
+	if want := []string{"7", "8"}; !reflect.DeepEqual(gotContacts, want) {
+		t.Errorf("listed contributions for contacts %v, want %v", gotContacts, want)
+	}
+	want := &GivingHistory{
+		ContactID: 7,
+		Household: []int{7, 8},
+		Years: []GivingYear{{
+			Year:      2023,
+			Purposes:  []PurposeGiving{{Purpose: Purpose{ID: 3}, Total: 140_00, Count: 2, SoftTotal: 1000_00, SoftCount: 1}},
+			Total:     140_00,
+			Count:     2,
+			SoftTotal: 1000_00,
+			SoftCount: 1,
+		}},
+		Total:     140_00,
+		Count:     2,
+		SoftTotal: 1000_00,
+		SoftCount: 1,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GivingHistory() = %+v, want %+v", got, want)
+	}
+}
@@ -1,10 +1,11 @@
 module github.com/Silicon-Ally/aplos
 
-go 1.20
+go 1.24
 
 require (
 	golang.org/x/net v0.9.0
 	golang.org/x/oauth2 v0.7.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -0,0 +1,50 @@
+package aplos
+
+import "net/http"
+
+// WithRequestHook registers a function that's called with each request before
+// it's sent to the API, e.g. for logging or to add headers. The hook may modify
+// the request, which is a copy of the one the client made. Hooks are called in
+// the order they were registered, and aren't called for responses served from
+// the HTTP cache or for authentication requests. The Authorization header
+// isn't set yet when hooks are called, so it can't leak into logs.
+func WithRequestHook(hook func(*http.Request)) Option {
+	return func(o *clientOpts) {
+		o.requestHooks = append(o.requestHooks, hook)
+	}
+}
+
+// WithResponseHook registers a function that's called with each response from
+// the API, before the client reads it, e.g. for logging or metrics. Hooks must
+// not read or close the response body. Like request hooks, they're called in
+// the order they were registered, and not for cached responses or
+// authentication requests.
+func WithResponseHook(hook func(*http.Response)) Option {
+	return func(o *clientOpts) {
+		o.responseHooks = append(o.responseHooks, hook)
+	}
+}
+
+type hookTransport struct {
+	next          http.RoundTripper
+	requestHooks  []func(*http.Request)
+	responseHooks []func(*http.Response)
+}
+
+func (t *hookTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if len(t.requestHooks) > 0 {
+		// RoundTrippers must not modify the request they're given.
+		req = req.Clone(req.Context())
+		for _, h := range t.requestHooks {
+			h(req)
+		}
+	}
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	for _, h := range t.responseHooks {
+		h(resp)
+	}
+	return resp, nil
+}
@@ -0,0 +1,51 @@
+package aplos
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestHooks(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/funds/1", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Trace-Id"); got != "abc" {
+			t.Errorf("X-Trace-Id header = %q, want abc", got)
+		}
+		w.Header().Set("X-Request-Cost", "1")
+		fmt.Fprint(w, `{"status": 200, "data": {"fund": {"id": 1}}}`)
+	})
+	srv, key := newAuthServer(t, mux)
+
+	var calls []string
+	c, err := New("client-id", key,
+		WithBaseURL(srv.URL+"/api/v1"),
+		WithRequestHook(func(r *http.Request) {
+			calls = append(calls, "request 1 "+r.URL.Path+" auth="+r.Header.Get("Authorization"))
+			r.Header.Set("X-Trace-Id", "abc")
+		}),
+		WithRequestHook(func(r *http.Request) {
+			calls = append(calls, "request 2 trace="+r.Header.Get("X-Trace-Id"))
+		}),
+		WithResponseHook(func(r *http.Response) {
+			calls = append(calls, fmt.Sprintf("response %d cost=%s", r.StatusCode, r.Header.Get("X-Request-Cost")))
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if _, err := c.Fund(context.Background(), 1); err != nil {
+		t.Fatalf("Fund: %v", err)
+	}
+
+	want := []string{
+		"request 1 /api/v1/funds/1 auth=",
+		"request 2 trace=abc",
+		"response 200 cost=1",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("hook calls = %q, want %q", calls, want)
+	}
+}
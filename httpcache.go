@@ -0,0 +1,270 @@
+package aplos
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheStorage stores serialized HTTP responses for the cache enabled by
+// WithHTTPCache. Implementations must be safe for concurrent use.
+type CacheStorage interface {
+	// Get returns the response stored under key, if any.
+	Get(key string) ([]byte, bool)
+	// Set stores a response under key, replacing any existing entry.
+	Set(key string, resp []byte)
+	// Delete removes the response stored under key, if any.
+	Delete(key string)
+}
+
+// MemoryCacheStorage is a CacheStorage that keeps responses in memory. The zero
+// value is ready to use.
+type MemoryCacheStorage struct {
+	mu    sync.Mutex
+	items map[string][]byte
+}
+
+func (m *MemoryCacheStorage) Get(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v, ok := m.items[key]
+	return v, ok
+}
+
+func (m *MemoryCacheStorage) Set(key string, resp []byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.items == nil {
+		m.items = make(map[string][]byte)
+	}
+	m.items[key] = resp
+}
+
+func (m *MemoryCacheStorage) Delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.items, key)
+}
+
+// storedAtHeader records when a response was stored, so that its age can be
+// computed when it's read back out.
+const storedAtHeader = "X-Aplos-Cache-Stored-At"
+
+// cacheTransport is a private HTTP cache, as described in RFC 9111. Since a
+// Client only ever talks to one organization, responses marked 'private' are
+// cacheable, and only the URL is used as the cache key, after prefix. Clients
+// for different organizations sharing a storage use different prefixes.
+type cacheTransport struct {
+	next    http.RoundTripper
+	storage CacheStorage
+	prefix  string
+	now     func() time.Time
+}
+
+func newCacheTransport(next http.RoundTripper, s CacheStorage, prefix string) *cacheTransport {
+	return &cacheTransport{next: next, storage: s, prefix: prefix, now: time.Now}
+}
+
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		resp, err := t.next.RoundTrip(req)
+		if err == nil && isUnsafeMethod(req.Method) && resp.StatusCode < 400 {
+			// Successful writes invalidate any cached copy of the target, see RFC
+			// 9111, section 4.4.
+			t.storage.Delete(t.prefix + req.URL.String())
+		}
+		return resp, err
+	}
+
+	key := t.prefix + req.URL.String()
+	reqCC := parseCacheControl(req.Header.Get("Cache-Control"))
+	if reqCC.has("no-store") {
+		return t.next.RoundTrip(req)
+	}
+
+	cached := t.load(key, req)
+	if cached != nil && !reqCC.has("no-cache") && t.isFresh(cached) {
+		return cached, nil
+	}
+
+	outReq := req
+	if cached != nil {
+		outReq = req.Clone(req.Context())
+		if etag := cached.Header.Get("ETag"); etag != "" {
+			outReq.Header.Set("If-None-Match", etag)
+		}
+		if lm := cached.Header.Get("Last-Modified"); lm != "" {
+			outReq.Header.Set("If-Modified-Since", lm)
+		}
+	}
+
+	resp, err := t.next.RoundTrip(outReq)
+	if err != nil {
+		if cached != nil {
+			cached.Body.Close()
+		}
+		return nil, err
+	}
+
+	if cached != nil && resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		// Update the stored headers with the ones from the validation response,
+		// per RFC 9111, section 4.3.4.
+		for k, vs := range resp.Header {
+			cached.Header[k] = vs
+		}
+		if err := t.store(key, cached); err != nil {
+			return nil, err
+		}
+		return t.load(key, req), nil
+	}
+	if cached != nil {
+		cached.Body.Close()
+	}
+
+	if !t.isCacheable(resp) {
+		t.storage.Delete(key)
+		return resp, nil
+	}
+	if err := t.store(key, resp); err != nil {
+		return nil, err
+	}
+	return t.load(key, req), nil
+}
+
+// store serializes resp into storage. It consumes resp.Body.
+func (t *cacheTransport) store(key string, resp *http.Response) error {
+	resp.Header.Set(storedAtHeader, t.now().UTC().Format(http.TimeFormat))
+	dat, err := httputil.DumpResponse(resp, true)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+	t.storage.Set(key, dat)
+	return nil
+}
+
+func (t *cacheTransport) load(key string, req *http.Request) *http.Response {
+	dat, ok := t.storage.Get(key)
+	if !ok {
+		return nil
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(dat)), req)
+	if err != nil {
+		// Corrupt entries are treated as a cache miss.
+		t.storage.Delete(key)
+		return nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		t.storage.Delete(key)
+		return nil
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp
+}
+
+func (t *cacheTransport) isCacheable(resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNonAuthoritativeInfo, http.StatusMovedPermanently, http.StatusNotFound:
+	default:
+		return false
+	}
+	cc := parseCacheControl(resp.Header.Get("Cache-Control"))
+	if cc.has("no-store") {
+		return false
+	}
+	// Without explicit freshness or a validator, a stored response could never be
+	// used, so don't bother storing it.
+	_, hasFreshness := freshnessLifetime(resp)
+	return hasFreshness || resp.Header.Get("ETag") != "" || resp.Header.Get("Last-Modified") != ""
+}
+
+func (t *cacheTransport) isFresh(resp *http.Response) bool {
+	cc := parseCacheControl(resp.Header.Get("Cache-Control"))
+	if cc.has("no-cache") {
+		return false
+	}
+	lifetime, ok := freshnessLifetime(resp)
+	if !ok {
+		return false
+	}
+	return t.age(resp) < lifetime
+}
+
+// age computes the current age of a stored response, per RFC 9111, section
+// 4.2.3, simplified for responses we store as soon as they're received.
+func (t *cacheTransport) age(resp *http.Response) time.Duration {
+	var age time.Duration
+	if v, err := strconv.Atoi(resp.Header.Get("Age")); err == nil && v > 0 {
+		age = time.Duration(v) * time.Second
+	}
+	if storedAt, err := http.ParseTime(resp.Header.Get(storedAtHeader)); err == nil {
+		if d := t.now().Sub(storedAt); d > 0 {
+			age += d
+		}
+	}
+	return age
+}
+
+// freshnessLifetime returns how long a response is fresh for, and false if the
+// response doesn't specify. Heuristic freshness isn't used.
+func freshnessLifetime(resp *http.Response) (time.Duration, bool) {
+	cc := parseCacheControl(resp.Header.Get("Cache-Control"))
+	if v, ok := cc["max-age"]; ok {
+		secs, err := strconv.Atoi(v)
+		if err != nil || secs < 0 {
+			return 0, true
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if v := resp.Header.Get("Expires"); v != "" {
+		expires, err := http.ParseTime(v)
+		if err != nil {
+			// Invalid Expires values represent a time in the past.
+			return 0, true
+		}
+		date, err := http.ParseTime(resp.Header.Get("Date"))
+		if err != nil {
+			return 0, true
+		}
+		return expires.Sub(date), true
+	}
+	return 0, false
+}
+
+type cacheControl map[string]string
+
+func (c cacheControl) has(directive string) bool {
+	_, ok := c[directive]
+	return ok
+}
+
+func parseCacheControl(v string) cacheControl {
+	cc := cacheControl{}
+	for _, part := range strings.Split(v, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, val, _ := strings.Cut(part, "=")
+		cc[strings.ToLower(strings.TrimSpace(name))] = strings.Trim(strings.TrimSpace(val), `"`)
+	}
+	return cc
+}
+
+func isUnsafeMethod(m string) bool {
+	switch m {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,110 @@
+package aplos
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCacheTransport(t *testing.T) {
+	tests := []struct {
+		desc string
+		// headers are set on every response from the test server.
+		headers map[string]string
+		// advance is how far to move the clock between requests.
+		advance  time.Duration
+		wantHits int
+		want304  int
+	}{
+		{
+			desc:     "fresh response is served from cache",
+			headers:  map[string]string{"Cache-Control": "max-age=60"},
+			advance:  30 * time.Second,
+			wantHits: 1,
+		},
+		{
+			desc:     "stale response is refetched",
+			headers:  map[string]string{"Cache-Control": "max-age=60"},
+			advance:  90 * time.Second,
+			wantHits: 2,
+		},
+		{
+			desc:     "stale response is revalidated",
+			headers:  map[string]string{"Cache-Control": "max-age=60", "ETag": `"v1"`},
+			advance:  90 * time.Second,
+			wantHits: 2,
+			want304:  1,
+		},
+		{
+			desc:     "no-cache always revalidates",
+			headers:  map[string]string{"Cache-Control": "no-cache", "ETag": `"v1"`},
+			wantHits: 2,
+			want304:  1,
+		},
+		{
+			desc:     "no-store is never cached",
+			headers:  map[string]string{"Cache-Control": "no-store, max-age=60"},
+			wantHits: 2,
+		},
+		{
+			desc:     "expires header",
+			headers:  map[string]string{"Date": "Mon, 01 May 2023 12:00:00 GMT", "Expires": "Mon, 01 May 2023 12:01:00 GMT"},
+			advance:  30 * time.Second,
+			wantHits: 1,
+		},
+		{
+			desc:     "no freshness information",
+			wantHits: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			var hits, notModified int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				hits++
+				for k, v := range test.headers {
+					w.Header().Set(k, v)
+				}
+				if etag := test.headers["ETag"]; etag != "" && r.Header.Get("If-None-Match") == etag {
+					notModified++
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				fmt.Fprint(w, "hello")
+			}))
+			defer srv.Close()
+
+			now := time.Date(2023, time.May, 1, 12, 0, 0, 0, time.UTC)
+			ct := newCacheTransport(http.DefaultTransport, &MemoryCacheStorage{}, "")
+			ct.now = func() time.Time { return now }
+			c := &http.Client{Transport: ct}
+
+			for i := 0; i < 2; i++ {
+				resp, err := c.Get(srv.URL + "/accounts")
+				if err != nil {
+					t.Fatalf("request %d: %v", i, err)
+				}
+				body, err := io.ReadAll(resp.Body)
+				resp.Body.Close()
+				if err != nil {
+					t.Fatalf("failed to read body %d: %v", i, err)
+				}
+				if resp.StatusCode != http.StatusOK || string(body) != "hello" {
+					t.Errorf("request %d = %d %q, want 200 %q", i, resp.StatusCode, body, "hello")
+				}
+				now = now.Add(test.advance)
+			}
+
+			if hits != test.wantHits {
+				t.Errorf("server was hit %d times, want %d", hits, test.wantHits)
+			}
+			if notModified != test.want304 {
+				t.Errorf("server returned %d 304s, want %d", notModified, test.want304)
+			}
+		})
+	}
+}
@@ -0,0 +1,328 @@
+package importer
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/Silicon-Ally/aplos"
+)
+
+// ContactService is the subset of *aplos.Client used to import contacts.
+type ContactService interface {
+	Contacts(ctx context.Context, opts ...aplos.ListContactOption) ([]aplos.Contact, error)
+	CreateContact(ctx context.Context, contact aplos.Contact) (*aplos.Contact, error)
+	UpdateContact(ctx context.Context, contact aplos.Contact) (*aplos.Contact, error)
+}
+
+// ContactMapping maps the columns of a CSV file to contact fields. Each field
+// holds the header of the column to read it from, matched case-insensitively,
+// and empty fields aren't imported. It can be loaded from a JSON config file.
+type ContactMapping struct {
+	// Type is the column holding the contact type, "individual" or "company".
+	// If unset or blank for a row, DefaultType is used, and failing that, rows
+	// with only a company name are imported as companies.
+	Type        string            `json:"type"`
+	DefaultType aplos.ContactType `json:"default_type"`
+
+	CompanyName string `json:"company_name"`
+	FirstName   string `json:"first_name"`
+	LastName    string `json:"last_name"`
+	Email       string `json:"email"`
+	Phone       string `json:"phone"`
+
+	Address1   string `json:"address1"`
+	Address2   string `json:"address2"`
+	City       string `json:"city"`
+	State      string `json:"state"`
+	PostalCode string `json:"postal_code"`
+	Country    string `json:"country"`
+}
+
+func (m *ContactMapping) columns() []*string {
+	return []*string{
+		&m.Type, &m.CompanyName, &m.FirstName, &m.LastName, &m.Email, &m.Phone,
+		&m.Address1, &m.Address2, &m.City, &m.State, &m.PostalCode, &m.Country,
+	}
+}
+
+// ImportContacts reads contacts from the CSV file in r, which must start with a
+// header row, and creates them in Aplos.
+//
+// Rows are matched against existing contacts, and earlier rows in the same
+// file, first by email address and then by name, ignoring case. Matched
+// contacts are updated with any fields that differ in the row. Existing
+// addresses and phone numbers are kept, and the row's are only added to
+// contacts that don't have any.
+//
+// Errors importing individual rows are reported in the results, which have one
+// entry per row. An error is only returned if the file or mapping is invalid,
+// existing contacts couldn't be loaded, or ctx is done, in which case the
+// results for rows processed so far are also returned.
+func ImportContacts(ctx context.Context, svc ContactService, r io.Reader, m ContactMapping, opts ...ImportOption) ([]RowResult, error) {
+	o := &importOpts{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	cols := make(map[string]int)
+	for i, h := range header {
+		cols[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	idx := make(map[*string]int)
+	for _, col := range m.columns() {
+		if *col == "" {
+			continue
+		}
+		i, ok := cols[strings.ToLower(strings.TrimSpace(*col))]
+		if !ok {
+			return nil, fmt.Errorf("mapped column %q not found in header", *col)
+		}
+		idx[col] = i
+	}
+
+	existing, err := svc.Contacts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load existing contacts: %w", err)
+	}
+	ix := newContactIndex()
+	for i := range existing {
+		ix.add(&existing[i])
+	}
+
+	var results []RowResult
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var perr *csv.ParseError
+			if !errors.As(err, &perr) {
+				return results, fmt.Errorf("failed to read row: %w", err)
+			}
+			results = append(results, RowResult{Line: perr.StartLine, Action: Failed, Err: err})
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		line, _ := cr.FieldPos(0)
+
+		field := func(col *string) string {
+			i, ok := idx[col]
+			if !ok || i >= len(rec) {
+				return ""
+			}
+			return strings.TrimSpace(rec[i])
+		}
+		res := importContact(ctx, svc, ix, contactFromRow(&m, field), o)
+		res.Line = line
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+func contactFromRow(m *ContactMapping, field func(*string) string) aplos.Contact {
+	c := aplos.Contact{
+		Type:        aplos.ContactType(strings.ToLower(field(&m.Type))),
+		CompanyName: field(&m.CompanyName),
+		FirstName:   field(&m.FirstName),
+		LastName:    field(&m.LastName),
+		Email:       field(&m.Email),
+	}
+	if c.Type == "" {
+		c.Type = m.DefaultType
+	}
+	if c.Type == "" {
+		c.Type = aplos.ContactTypeIndividual
+		if c.CompanyName != "" && c.FirstName == "" && c.LastName == "" {
+			c.Type = aplos.ContactTypeCompany
+		}
+	}
+	if c.Email != "" {
+		c.Emails = []aplos.ContactEmail{{Address: c.Email, IsPrimary: true}}
+	}
+	if p := field(&m.Phone); p != "" {
+		c.Phones = []aplos.ContactPhone{{Number: p, IsPrimary: true}}
+	}
+	addr := aplos.ContactAddress{
+		Address1:   field(&m.Address1),
+		Address2:   field(&m.Address2),
+		City:       field(&m.City),
+		State:      field(&m.State),
+		PostalCode: field(&m.PostalCode),
+		Country:    field(&m.Country),
+		IsPrimary:  true,
+	}
+	if addr != (aplos.ContactAddress{IsPrimary: true}) {
+		c.Addresses = []aplos.ContactAddress{addr}
+	}
+	return c
+}
+
+func importContact(ctx context.Context, svc ContactService, ix *contactIndex, row aplos.Contact, o *importOpts) RowResult {
+	switch row.Type {
+	case aplos.ContactTypeIndividual, aplos.ContactTypeCompany:
+	default:
+		return RowResult{Action: Failed, Err: fmt.Errorf("invalid contact type %q", row.Type)}
+	}
+	if nameKey(&row) == "" && row.Email == "" {
+		return RowResult{Action: Failed, Err: errors.New("row has no name or email")}
+	}
+
+	match, matchedBy := ix.find(&row)
+	if match == nil {
+		if o.dryRun {
+			ix.add(&row)
+			return RowResult{Action: Created}
+		}
+		created, err := svc.CreateContact(ctx, row)
+		if err != nil {
+			return RowResult{Action: Failed, Err: err}
+		}
+		ix.add(created)
+		return RowResult{Action: Created, ID: created.ID}
+	}
+
+	res := RowResult{ID: match.ID, MatchedBy: matchedBy}
+	merged, changed := mergeContact(*match, row)
+	if !changed {
+		res.Action = Unchanged
+		return res
+	}
+	res.Action = Updated
+	if o.dryRun {
+		*match = merged
+		return res
+	}
+	updated, err := svc.UpdateContact(ctx, merged)
+	if err != nil {
+		res.Action, res.Err = Failed, err
+		return res
+	}
+	*match = *updated
+	ix.add(match)
+	return res
+}
+
+// mergeContact copies the fields set on row onto existing, reporting whether
+// anything changed.
+func mergeContact(existing, row aplos.Contact) (aplos.Contact, bool) {
+	changed := false
+	set := func(dst *string, v string) {
+		if v != "" && *dst != v {
+			*dst = v
+			changed = true
+		}
+	}
+	set(&existing.CompanyName, row.CompanyName)
+	set(&existing.FirstName, row.FirstName)
+	set(&existing.LastName, row.LastName)
+	// Contacts matched by name may have a different address, which is added
+	// alongside the existing ones rather than replacing the primary address.
+	if row.Email != "" && !hasEmail(&existing, row.Email) {
+		e := aplos.ContactEmail{Address: row.Email, IsPrimary: existing.Email == ""}
+		if e.IsPrimary {
+			existing.Email = row.Email
+		}
+		existing.Emails = append(existing.Emails, e)
+		changed = true
+	}
+	if len(existing.Addresses) == 0 && len(row.Addresses) > 0 {
+		existing.Addresses = row.Addresses
+		changed = true
+	}
+	if len(existing.Phones) == 0 && len(row.Phones) > 0 {
+		existing.Phones = row.Phones
+		changed = true
+	}
+	return existing, changed
+}
+
+// hasEmail reports whether the contact has the given email address, as its
+// primary address or otherwise.
+func hasEmail(c *aplos.Contact, email string) bool {
+	if strings.EqualFold(c.Email, email) {
+		return true
+	}
+	for _, e := range c.Emails {
+		if strings.EqualFold(e.Address, email) {
+			return true
+		}
+	}
+	return false
+}
+
+// contactIndex finds contacts by email address or name.
+type contactIndex struct {
+	byEmail map[string]*aplos.Contact
+	// byName can have several contacts with the same name, e.g. two donors
+	// named John Smith with different email addresses.
+	byName map[string][]*aplos.Contact
+}
+
+func newContactIndex() *contactIndex {
+	return &contactIndex{
+		byEmail: make(map[string]*aplos.Contact),
+		byName:  make(map[string][]*aplos.Contact),
+	}
+}
+
+func (ix *contactIndex) add(c *aplos.Contact) {
+	if e := strings.ToLower(c.Email); e != "" {
+		ix.byEmail[e] = c
+	}
+	for _, e := range c.Emails {
+		if e := strings.ToLower(e.Address); e != "" {
+			if _, ok := ix.byEmail[e]; !ok {
+				ix.byEmail[e] = c
+			}
+		}
+	}
+	if k := nameKey(c); k != "" && !slices.Contains(ix.byName[k], c) {
+		ix.byName[k] = append(ix.byName[k], c)
+	}
+}
+
+func (ix *contactIndex) find(c *aplos.Contact) (*aplos.Contact, string) {
+	if e := strings.ToLower(c.Email); e != "" {
+		if m, ok := ix.byEmail[e]; ok {
+			return m, "email"
+		}
+	}
+	// A contact with a different email address is a different person, even if
+	// they share a name, so names only match if one side has no email.
+	if k := nameKey(c); k != "" {
+		for _, m := range ix.byName[k] {
+			if c.Email == "" || (m.Email == "" && len(m.Emails) == 0) {
+				return m, "name"
+			}
+		}
+	}
+	return nil, ""
+}
+
+func nameKey(c *aplos.Contact) string {
+	var name string
+	if c.Type == aplos.ContactTypeCompany {
+		name = c.CompanyName
+	} else if c.FirstName != "" || c.LastName != "" {
+		name = c.FirstName + " " + c.LastName
+	}
+	name = strings.Join(strings.Fields(strings.ToLower(name)), " ")
+	if name == "" {
+		return ""
+	}
+	return string(c.Type) + ":" + name
+}
@@ -0,0 +1,210 @@
+package importer
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Silicon-Ally/aplos"
+)
+
+type fakeContacts struct {
+	contacts []aplos.Contact
+	nextID   int
+	created  []aplos.Contact
+	updated  []aplos.Contact
+}
+
+func (f *fakeContacts) Contacts(ctx context.Context, opts ...aplos.ListContactOption) ([]aplos.Contact, error) {
+	return f.contacts, nil
+}
+
+func (f *fakeContacts) CreateContact(ctx context.Context, c aplos.Contact) (*aplos.Contact, error) {
+	if c.LastName == "Fail" {
+		return nil, errors.New("boom")
+	}
+	f.nextID++
+	c.ID = f.nextID
+	f.created = append(f.created, c)
+	return &c, nil
+}
+
+func (f *fakeContacts) UpdateContact(ctx context.Context, c aplos.Contact) (*aplos.Contact, error) {
+	f.updated = append(f.updated, c)
+	return &c, nil
+}
+
+const contactsCSV = `First,Last,Organization,E-mail,Phone,Zip
+Grace,Hopper,,GRACE@example.com,555-0100,22201
+Ada,Lovelace,,,,
+Ada,Lovelace,,ada@example.com,,
+,,Acme Corp,,,
+Bad,Fail,,,,
+,,,,,
+Alan,Turing,,alan@example.com,,
+`
+
+func TestImportContacts(t *testing.T) {
+	svc := &fakeContacts{
+		nextID: 100,
+		contacts: []aplos.Contact{
+			{ID: 1, Type: aplos.ContactTypeIndividual, FirstName: "Grace", LastName: "Hopper", Email: "grace@example.com"},
+			{ID: 2, Type: aplos.ContactTypeIndividual, FirstName: "Alan", LastName: "Turing", Email: "alan@example.com"},
+		},
+	}
+	m := ContactMapping{
+		FirstName:   "first",
+		LastName:    "last",
+		CompanyName: "organization",
+		Email:       "e-mail",
+		Phone:       "phone",
+		PostalCode:  "zip",
+	}
+
+	got, err := ImportContacts(context.Background(), svc, strings.NewReader(contactsCSV), m)
+	if err != nil {
+		t.Fatalf("ImportContacts: %v", err)
+	}
+
+	// Errors are compared separately.
+	var errLines []int
+	for i := range got {
+		if got[i].Err != nil {
+			errLines = append(errLines, got[i].Line)
+			got[i].Err = nil
+		}
+	}
+	want := []RowResult{
+		{Line: 2, Action: Updated, ID: 1, MatchedBy: "email"},
+		{Line: 3, Action: Created, ID: 101},
+		{Line: 4, Action: Updated, ID: 101, MatchedBy: "name"},
+		{Line: 5, Action: Created, ID: 102},
+		{Line: 6, Action: Failed},
+		{Line: 7, Action: Failed},
+		{Line: 8, Action: Unchanged, ID: 2, MatchedBy: "email"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ImportContacts() = %+v, want %+v", got, want)
+	}
+	if want := []int{6, 7}; !reflect.DeepEqual(errLines, want) {
+		t.Errorf("rows with errors = %v, want %v", errLines, want)
+	}
+
+	if n := len(svc.created); n != 2 {
+		t.Fatalf("%d contacts were created, want 2", n)
+	}
+	if got := svc.created[1]; got.Type != aplos.ContactTypeCompany || got.CompanyName != "Acme Corp" {
+		t.Errorf("created contact = %+v, want company Acme Corp", got)
+	}
+	if n := len(svc.updated); n != 2 {
+		t.Fatalf("%d contacts were updated, want 2", n)
+	}
+	grace := svc.updated[0]
+	if grace.Email != "grace@example.com" || len(grace.Phones) != 1 || len(grace.Addresses) != 1 || grace.Addresses[0].PostalCode != "22201" {
+		t.Errorf("updated contact = %+v, want existing email with added phone and address", grace)
+	}
+
+	if got, want := Summary(got), map[Action]int{Created: 2, Updated: 2, Unchanged: 1, Failed: 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Summary() = %v, want %v", got, want)
+	}
+}
+
+func TestImportContactsMalformedRow(t *testing.T) {
+	const in = `First,Last,E-mail
+Grace,Hopper,grace@example.com
+B"ad,Row,bad@example.com
+Alan,Turing,alan@example.com
+`
+	svc := &fakeContacts{nextID: 100}
+	m := ContactMapping{FirstName: "first", LastName: "last", Email: "e-mail"}
+
+	got, err := ImportContacts(context.Background(), svc, strings.NewReader(in), m)
+	if err != nil {
+		t.Fatalf("ImportContacts: %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("got %d results, want 3: %+v", len(got), got)
+	}
+	wantActions := []Action{Created, Failed, Created}
+	wantLines := []int{2, 3, 4}
+	for i, r := range got {
+		if r.Action != wantActions[i] || r.Line != wantLines[i] {
+			t.Errorf("result %d = line %d, %s, want line %d, %s", i, r.Line, r.Action, wantLines[i], wantActions[i])
+		}
+	}
+	var perr *csv.ParseError
+	if !errors.As(got[1].Err, &perr) {
+		t.Errorf("result 1 error = %v, want a *csv.ParseError", got[1].Err)
+	}
+	if len(svc.created) != 2 {
+		t.Errorf("created %d contacts, want 2", len(svc.created))
+	}
+}
+
+func TestImportContactsDryRun(t *testing.T) {
+	svc := &fakeContacts{}
+	got, err := ImportContacts(context.Background(), svc, strings.NewReader(contactsCSV), ContactMapping{FirstName: "First", LastName: "Last"}, WithDryRun())
+	if err != nil {
+		t.Fatalf("ImportContacts: %v", err)
+	}
+	if len(svc.created) != 0 || len(svc.updated) != 0 {
+		t.Errorf("dry run created %d and updated %d contacts, want none", len(svc.created), len(svc.updated))
+	}
+	if got, want := Summary(got), map[Action]int{Created: 4, Unchanged: 1, Failed: 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Summary() = %v, want %v", got, want)
+	}
+}
+
+func TestImportContactsUnknownColumn(t *testing.T) {
+	_, err := ImportContacts(context.Background(), &fakeContacts{}, strings.NewReader(contactsCSV), ContactMapping{Email: "email_address"})
+	if err == nil {
+		t.Error("ImportContacts() returned no error for an unknown column, want one")
+	}
+}
+
+func TestImportContactsSameName(t *testing.T) {
+	svc := &fakeContacts{
+		nextID: 100,
+		contacts: []aplos.Contact{
+			{ID: 1, Type: aplos.ContactTypeIndividual, FirstName: "John", LastName: "Smith", Email: "john@example.com",
+				Emails: []aplos.ContactEmail{{Address: "john@example.com", IsPrimary: true}}},
+		},
+	}
+	const rows = `First,Last,Email
+John,Smith,jsmith@example.org
+John,Smith,
+`
+	got, err := ImportContacts(context.Background(), svc, strings.NewReader(rows), ContactMapping{FirstName: "first", LastName: "last", Email: "email"})
+	if err != nil {
+		t.Fatalf("ImportContacts: %v", err)
+	}
+	want := []RowResult{
+		{Line: 2, Action: Created, ID: 101},
+		{Line: 3, Action: Unchanged, ID: 1, MatchedBy: "name"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ImportContacts() = %+v, want %+v", got, want)
+	}
+	if len(svc.updated) != 0 {
+		t.Errorf("updated %+v, want the existing contact left alone", svc.updated)
+	}
+}
+
+func TestMergeContactEmail(t *testing.T) {
+	noEmail := aplos.Contact{ID: 1, FirstName: "Ada", LastName: "Lovelace"}
+	row := aplos.Contact{FirstName: "Ada", LastName: "Lovelace", Email: "ada@example.com"}
+	merged, changed := mergeContact(noEmail, row)
+	if !changed || merged.Email != "ada@example.com" || !reflect.DeepEqual(merged.Emails, []aplos.ContactEmail{{Address: "ada@example.com", IsPrimary: true}}) {
+		t.Errorf("mergeContact() = %+v, %t, want ada@example.com added as the primary email", merged, changed)
+	}
+
+	withEmail := aplos.Contact{ID: 1, Email: "ada@example.com", Emails: []aplos.ContactEmail{{Address: "ada@example.com", IsPrimary: true}}}
+	merged, _ = mergeContact(withEmail, aplos.Contact{Email: "countess@example.org"})
+	wantEmails := []aplos.ContactEmail{{Address: "ada@example.com", IsPrimary: true}, {Address: "countess@example.org"}}
+	if merged.Email != "ada@example.com" || !reflect.DeepEqual(merged.Emails, wantEmails) {
+		t.Errorf("mergeContact() = %+v, want the primary email kept and the new one added as non-primary", merged)
+	}
+}
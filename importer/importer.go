@@ -0,0 +1,75 @@
+// Package importer loads records from other systems, like CSV exports from a
+// donor database, into Aplos.
+//
+// Importers make one API request per created or updated record. They don't do
+// any pacing of their own, so to stay under Aplos's rate limits, use a Client
+// created with aplos.WithRateLimits.
+package importer
+
+import "github.com/Silicon-Ally/aplos/ledger"
+
+// Action describes what an importer did with a single input row.
+type Action string
+
+const (
+	// Created means a new record was created from the row.
+	Created Action = "created"
+	// Updated means the row matched an existing record, which was updated.
+	Updated Action = "updated"
+	// Unchanged means the row matched an existing record that already had all
+	// of the row's data.
+	Unchanged Action = "unchanged"
+	// Failed means the row couldn't be imported, see RowResult.Err.
+	Failed Action = "failed"
+	// Deleted means a record created by an import was deleted by Rollback.
+	Deleted Action = "deleted"
+)
+
+// RowResult is the outcome of importing a single input row.
+type RowResult struct {
+	// Line is the line of the input the row started on, where the header is on
+	// line 1.
+	Line   int
+	Action Action
+	// ID is the ID of the created or matched record, if any.
+	ID int
+	// MatchedBy describes how the row was matched to an existing record, e.g.
+	// "email", or is empty if it wasn't.
+	MatchedBy string
+	// Err is set if Action is Failed.
+	Err error
+}
+
+// Summary counts the results by action.
+func Summary(results []RowResult) map[Action]int {
+	out := make(map[Action]int)
+	for _, r := range results {
+		out[r.Action]++
+	}
+	return out
+}
+
+type importOpts struct {
+	dryRun bool
+	memos  *ledger.MemoFormat
+}
+
+// WithDryRun reports what an import would do without creating or updating
+// anything. Rows that would be created are reported as Created, with no ID.
+func WithDryRun() ImportOption {
+	return func(o *importOpts) {
+		o.dryRun = true
+	}
+}
+
+// WithMemoFormat applies a memo format's prefix and maximum length to the memos
+// of imported transactions, so they match entries generated by the ledger
+// package with the same format. ImportTransactions makes room for the batch
+// token within the maximum length. Other imports ignore it.
+func WithMemoFormat(f *ledger.MemoFormat) ImportOption {
+	return func(o *importOpts) {
+		o.memos = f
+	}
+}
+
+type ImportOption func(*importOpts)
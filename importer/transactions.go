@@ -0,0 +1,138 @@
+package importer
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Silicon-Ally/aplos"
+)
+
+// TransactionService is the subset of *aplos.Client used to import
+// transactions and roll them back.
+type TransactionService interface {
+	Transactions(ctx context.Context, opts ...aplos.ListTransactionOption) ([]aplos.Transaction, error)
+	CreateTransaction(ctx context.Context, txn aplos.Transaction) (*aplos.Transaction, error)
+	DeleteTransaction(ctx context.Context, id int) error
+}
+
+// NewBatchID returns a new identifier for an import batch, made of the current
+// date and a random suffix, like "20230507-9f86d081".
+func NewBatchID() string {
+	var b [4]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(fmt.Sprintf("failed to read random bytes: %v", err))
+	}
+	return time.Now().Format("20060102") + "-" + hex.EncodeToString(b[:])
+}
+
+// BatchToken returns the token that ImportTransactions adds to the memo of each
+// transaction in the given batch, like "[import:20230507-9f86d081]".
+func BatchToken(batchID string) string {
+	return "[import:" + batchID + "]"
+}
+
+func validateBatchID(batchID string) error {
+	if batchID == "" {
+		return errors.New("a batch ID is required")
+	}
+	if strings.ContainsAny(batchID, "[] \t\r\n") {
+		return fmt.Errorf("invalid batch ID %q, it can't contain brackets or whitespace", batchID)
+	}
+	return nil
+}
+
+// ImportTransactions creates the given transactions, e.g. entries generated by
+// the ledger package, in Aplos. Each transaction's memo is stamped with the
+// batch's token, see BatchToken, so the whole batch can be found, and undone
+// with Rollback if the import goes wrong. Use NewBatchID to pick a batch ID,
+// and record it alongside the import. With WithMemoFormat, the memos are
+// also normalized and truncated to the format's maximum length, keeping the
+// token intact.
+//
+// Results have one entry per transaction, where Line is the transaction's
+// position in txns, starting at 1. Errors creating individual transactions are
+// reported in the results, and an error is only returned if the batch ID is
+// invalid or ctx is done.
+func ImportTransactions(ctx context.Context, svc TransactionService, txns []aplos.Transaction, batchID string, opts ...ImportOption) ([]RowResult, error) {
+	if err := validateBatchID(batchID); err != nil {
+		return nil, err
+	}
+	o := &importOpts{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	token := BatchToken(batchID)
+	var results []RowResult
+	for i, txn := range txns {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		res := RowResult{Line: i + 1, Action: Created}
+		txn.Memo = o.memos.Append(txn.Memo, token)
+		if !o.dryRun {
+			created, err := svc.CreateTransaction(ctx, txn)
+			if err != nil {
+				res.Action, res.Err = Failed, err
+			} else {
+				res.ID = created.ID
+			}
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// Rollback deletes every transaction whose memo has the given batch's token,
+// i.e. everything ImportTransactions created in the batch. With WithDryRun,
+// the transactions are reported as Deleted without deleting them.
+//
+// Results have one entry per transaction, with Line unset. Transactions that
+// couldn't be deleted, e.g. because they're in a closed period, are reported
+// as Failed, and the rest are still deleted, so a rollback can be retried
+// after fixing the problem. An error is only returned if the batch ID is
+// invalid, the transactions couldn't be listed, or ctx is done.
+func Rollback(ctx context.Context, svc TransactionService, batchID string, opts ...ImportOption) ([]RowResult, error) {
+	if err := validateBatchID(batchID); err != nil {
+		return nil, err
+	}
+	o := &importOpts{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	token := BatchToken(batchID)
+	// The range start is set explicitly so that a client's default lookback
+	// doesn't hide older batches.
+	txns, err := svc.Transactions(ctx,
+		aplos.WithMemoContains(token),
+		aplos.WithRangeStart(1900, time.January, 1),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find transactions in batch %s: %w", batchID, err)
+	}
+
+	var results []RowResult
+	for _, txn := range txns {
+		// The memo filter is applied by Aplos, so check for the exact token.
+		if !strings.Contains(txn.Memo, token) {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		res := RowResult{Action: Deleted, ID: txn.ID}
+		if !o.dryRun {
+			if err := svc.DeleteTransaction(ctx, txn.ID); err != nil {
+				res.Action, res.Err = Failed, err
+			}
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
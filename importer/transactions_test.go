@@ -0,0 +1,114 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Silicon-Ally/aplos"
+	"github.com/Silicon-Ally/aplos/ledger"
+)
+
+type fakeTransactions struct {
+	txns    []aplos.Transaction
+	nextID  int
+	deleted []int
+}
+
+func (f *fakeTransactions) Transactions(ctx context.Context, opts ...aplos.ListTransactionOption) ([]aplos.Transaction, error) {
+	return f.txns, nil
+}
+
+func (f *fakeTransactions) CreateTransaction(ctx context.Context, txn aplos.Transaction) (*aplos.Transaction, error) {
+	if strings.HasPrefix(txn.Memo, "Bad") {
+		return nil, errors.New("boom")
+	}
+	f.nextID++
+	txn.ID = f.nextID
+	f.txns = append(f.txns, txn)
+	return &txn, nil
+}
+
+func (f *fakeTransactions) DeleteTransaction(ctx context.Context, id int) error {
+	if id == 2 {
+		return errors.New("closed period")
+	}
+	f.deleted = append(f.deleted, id)
+	return nil
+}
+
+func TestImportAndRollback(t *testing.T) {
+	ctx := context.Background()
+	svc := &fakeTransactions{txns: []aplos.Transaction{{ID: 100, Memo: "Rent [import:b1-extra]"}}}
+	txns := []aplos.Transaction{{Memo: "Contribution from Grace Hopper"}, {Memo: ""}, {Memo: "Bad entry"}, {Memo: "Deposit"}}
+
+	results, err := ImportTransactions(ctx, svc, txns, "b1")
+	if err != nil {
+		t.Fatalf("ImportTransactions: %v", err)
+	}
+	var actions []Action
+	for _, r := range results {
+		actions = append(actions, r.Action)
+	}
+	if want := []Action{Created, Created, Failed, Created}; !reflect.DeepEqual(actions, want) {
+		t.Errorf("import actions = %v, want %v", actions, want)
+	}
+	var memos []string
+	for _, txn := range svc.txns[1:] {
+		memos = append(memos, txn.Memo)
+	}
+	if want := []string{"Contribution from Grace Hopper [import:b1]", "[import:b1]", "Deposit [import:b1]"}; !reflect.DeepEqual(memos, want) {
+		t.Errorf("created memos = %q, want %q", memos, want)
+	}
+
+	dry, err := Rollback(ctx, svc, "b1", WithDryRun())
+	if err != nil {
+		t.Fatalf("Rollback (dry run): %v", err)
+	}
+	if got, want := Summary(dry), map[Action]int{Deleted: 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("dry run summary = %v, want %v", got, want)
+	}
+	if len(svc.deleted) != 0 {
+		t.Errorf("dry run deleted %v, want nothing", svc.deleted)
+	}
+
+	results, err = Rollback(ctx, svc, "b1")
+	if err != nil {
+		t.Fatalf("Rollback: %v", err)
+	}
+	if got, want := Summary(results), map[Action]int{Deleted: 2, Failed: 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("rollback summary = %v, want %v", got, want)
+	}
+	if want := []int{1, 3}; !reflect.DeepEqual(svc.deleted, want) {
+		t.Errorf("deleted = %v, want %v", svc.deleted, want)
+	}
+}
+
+func TestImportTransactionsMemoFormat(t *testing.T) {
+	svc := &fakeTransactions{}
+	f := &ledger.MemoFormat{Prefix: "[sync] ", MaxLength: 30}
+	txns := []aplos.Transaction{{Memo: "Contribution   from Grace Hopper"}, {Memo: "[sync] Deposit"}}
+	if _, err := ImportTransactions(context.Background(), svc, txns, "b1", WithMemoFormat(f)); err != nil {
+		t.Fatalf("ImportTransactions: %v", err)
+	}
+	var memos []string
+	for _, txn := range svc.txns {
+		memos = append(memos, txn.Memo)
+	}
+	if want := []string{"[sync] Contribu... [import:b1]", "[sync] Deposit [import:b1]"}; !reflect.DeepEqual(memos, want) {
+		t.Errorf("created memos = %q, want %q", memos, want)
+	}
+}
+
+func TestInvalidBatchID(t *testing.T) {
+	for _, id := range []string{"", "a b", "x]"} {
+		if _, err := ImportTransactions(context.Background(), &fakeTransactions{}, nil, id); err == nil {
+			t.Errorf("ImportTransactions with batch ID %q returned no error", id)
+		}
+		if _, err := Rollback(context.Background(), &fakeTransactions{}, id); err == nil {
+			t.Errorf("Rollback with batch ID %q returned no error", id)
+		}
+	}
+}
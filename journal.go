@@ -0,0 +1,215 @@
+package aplos
+
+import (
+	"errors"
+	"fmt"
+)
+
+// UnbalancedError is returned when a journal entry's lines don't balance to
+// zero.
+type UnbalancedError struct {
+	// Debits and Credits are the totals of the entry's positive and negative
+	// lines, with Credits reported as a positive number.
+	Debits, Credits Amount
+}
+
+func (e *UnbalancedError) Error() string {
+	return fmt.Sprintf("journal entry doesn't balance: debits %s, credits %s, difference %s", e.Debits, e.Credits, e.Debits-e.Credits)
+}
+
+// validateEntry checks that txn is a valid journal entry, with a date and at
+// least two lines, each with an account, fund, and non-zero amount, that
+// balance to zero to the cent.
+func validateEntry(txn Transaction) error {
+	if txn.Date == (Date{}) {
+		return errors.New("transaction date must be set")
+	}
+	if len(txn.Lines) < 2 {
+		return fmt.Errorf("transaction must have at least two lines, had %d", len(txn.Lines))
+	}
+
+	var debits, credits Amount
+	for i, l := range txn.Lines {
+		switch {
+		case l.Account.AccountNumber == 0:
+			return fmt.Errorf("line %d has no account number", i)
+		case l.Fund.ID == 0:
+			return fmt.Errorf("line %d has no fund ID", i)
+		case l.Amount == 0:
+			return fmt.Errorf("line %d has a zero amount", i)
+		}
+		if l.Amount > 0 {
+			debits += l.Amount
+		} else {
+			credits -= l.Amount
+		}
+	}
+	if debits != credits {
+		return &UnbalancedError{Debits: debits, Credits: credits}
+	}
+	return nil
+}
+
+// JournalEntry builds a balanced Transaction for CreateTransaction one line at
+// a time, using debit and credit amounts rather than signed ones. Errors are
+// deferred until Build, so calls can be chained:
+//
+//	txn, err := aplos.NewJournalEntry(date, "Office supplies").
+//		Debit(5100, generalFund, aplos.NewAmount(42.50)).
+//		Credit(1000, generalFund, aplos.NewAmount(42.50)).
+//		Build()
+type JournalEntry struct {
+	txn Transaction
+	err error
+	// kind is "expense" or "deposit" for entries started with NewExpense or
+	// NewDeposit, and restricts how they can be balanced.
+	kind string
+}
+
+// NewJournalEntry starts a journal entry on the given date.
+func NewJournalEntry(date Date, memo string) *JournalEntry {
+	return &JournalEntry{txn: Transaction{Date: date, Memo: memo}}
+}
+
+// NewExpense starts a journal entry for an expense, which is split across
+// funds or accounts with Debit and paid with PaidFrom. It can't be balanced
+// with DepositedTo:
+//
+//	txn, err := aplos.NewExpense(date, "Conference travel").
+//		Debit(5200, grantFund, aplos.NewAmount(300)).
+//		Debit(5200, generalFund, aplos.NewAmount(150)).
+//		PaidFrom(1000).
+//		Build()
+func NewExpense(date Date, memo string) *JournalEntry {
+	j := NewJournalEntry(date, memo)
+	j.kind = "expense"
+	return j
+}
+
+// NewDeposit starts a journal entry for income, which is split across funds or
+// accounts with Credit and deposited with DepositedTo. It can't be balanced
+// with PaidFrom.
+func NewDeposit(date Date, memo string) *JournalEntry {
+	j := NewJournalEntry(date, memo)
+	j.kind = "deposit"
+	return j
+}
+
+// Contact sets the payee or payer of the entry.
+func (j *JournalEntry) Contact(id int) *JournalEntry {
+	j.txn.Contact = &Contact{ID: id}
+	return j
+}
+
+// RefNumber sets the entry's reference number, e.g. a check number.
+func (j *JournalEntry) RefNumber(n RefNumber) *JournalEntry {
+	j.txn.IDNumber = n
+	return j
+}
+
+// Debit adds a line debiting amount, which must be positive, to the given
+// account and fund.
+func (j *JournalEntry) Debit(accountNumber, fundID int, amount Amount) *JournalEntry {
+	return j.add("debit", accountNumber, fundID, amount, 1)
+}
+
+// Credit adds a line crediting amount, which must be positive, to the given
+// account and fund.
+func (j *JournalEntry) Credit(accountNumber, fundID int, amount Amount) *JournalEntry {
+	return j.add("credit", accountNumber, fundID, amount, -1)
+}
+
+// PaidFrom balances the entry by crediting the given account, e.g. a bank
+// account, with the net debits in each fund, so that each fund balances on its
+// own. It's meant to be called once, after the expense's debit lines. It's an
+// error if any fund has net credits, since that would debit the paying account,
+// or if the entry was started with NewDeposit.
+func (j *JournalEntry) PaidFrom(accountNumber int) *JournalEntry {
+	if j.err == nil && j.kind == "deposit" {
+		j.err = errors.New("deposit can't be paid from an account, use DepositedTo")
+	}
+	return j.balance(accountNumber, 1)
+}
+
+// DepositedTo balances the entry by debiting the given account, e.g. a bank
+// account, with the net credits in each fund, so that each fund balances on
+// its own. It's meant to be called once, after the income's credit lines. It's
+// an error if any fund has net debits, since that would credit the receiving
+// account, or if the entry was started with NewExpense.
+func (j *JournalEntry) DepositedTo(accountNumber int) *JournalEntry {
+	if j.err == nil && j.kind == "expense" {
+		j.err = errors.New("expense can't be deposited to an account, use PaidFrom")
+	}
+	return j.balance(accountNumber, -1)
+}
+
+// balance adds a line to the given account for each fund whose lines don't
+// net to zero, offsetting them. Funds are balanced in the order they first
+// appear. sign is the sign each fund's net must have, 1 for net debits and -1
+// for net credits.
+func (j *JournalEntry) balance(accountNumber int, sign Amount) *JournalEntry {
+	if j.err != nil {
+		return j
+	}
+	var funds []int
+	net := make(map[int]Amount)
+	for _, l := range j.txn.Lines {
+		if _, ok := net[l.Fund.ID]; !ok {
+			funds = append(funds, l.Fund.ID)
+		}
+		net[l.Fund.ID] += l.Amount
+	}
+	for _, id := range funds {
+		if net[id] == 0 {
+			continue
+		}
+		if net[id]*sign < 0 {
+			j.err = fmt.Errorf("fund %d has net %ss of %s, which can't be balanced by a %s to account %d", id, side(net[id]), net[id].Abs(), side(-net[id]), accountNumber)
+			return j
+		}
+		j.txn.Lines = append(j.txn.Lines, TransactionLine{
+			Amount:  -net[id],
+			Account: Account{AccountNumber: accountNumber},
+			Fund:    Fund{ID: id},
+		})
+	}
+	return j
+}
+
+// side returns "debit" for positive amounts and "credit" otherwise.
+func side(a Amount) string {
+	if a > 0 {
+		return "debit"
+	}
+	return "credit"
+}
+
+func (j *JournalEntry) add(kind string, accountNumber, fundID int, amount, sign Amount) *JournalEntry {
+	if j.err != nil {
+		return j
+	}
+	if amount <= 0 {
+		j.err = fmt.Errorf("%s to account %d must be positive, was %s", kind, accountNumber, amount)
+		return j
+	}
+	j.txn.Lines = append(j.txn.Lines, TransactionLine{
+		Amount:  sign * amount,
+		Account: Account{AccountNumber: accountNumber},
+		Fund:    Fund{ID: fundID},
+	})
+	return j
+}
+
+// Build validates the entry, returning the first error encountered while
+// building it, or an *UnbalancedError if its debits and credits don't match.
+func (j *JournalEntry) Build() (Transaction, error) {
+	if j.err != nil {
+		return Transaction{}, j.err
+	}
+	if err := validateEntry(j.txn); err != nil {
+		return Transaction{}, err
+	}
+	txn := j.txn
+	txn.Lines = append([]TransactionLine(nil), j.txn.Lines...)
+	return txn, nil
+}
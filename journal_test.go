@@ -0,0 +1,158 @@
+package aplos
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestJournalEntry(t *testing.T) {
+	date := d(2023, time.June, 1)
+	got, err := NewJournalEntry(date, "Office supplies").
+		Contact(7).
+		RefNumber(1042).
+		Debit(5100, 1, 30_10).
+		Debit(5100, 2, 12_40).
+		Credit(1000, 1, 30_10).
+		Credit(1000, 2, 12_40).
+		Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	want := Transaction{
+		Date:     date,
+		Memo:     "Office supplies",
+		IDNumber: 1042,
+		Contact:  &Contact{ID: 7},
+		Lines: []TransactionLine{
+			{Amount: 30_10, Account: Account{AccountNumber: 5100}, Fund: Fund{ID: 1}},
+			{Amount: 12_40, Account: Account{AccountNumber: 5100}, Fund: Fund{ID: 2}},
+			{Amount: -30_10, Account: Account{AccountNumber: 1000}, Fund: Fund{ID: 1}},
+			{Amount: -12_40, Account: Account{AccountNumber: 1000}, Fund: Fund{ID: 2}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Build() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSplitEntries(t *testing.T) {
+	date := d(2023, time.June, 1)
+	tests := []struct {
+		desc  string
+		entry *JournalEntry
+		want  []TransactionLine
+	}{
+		{
+			desc:  "expense split across funds",
+			entry: NewExpense(date, "Conference travel").Debit(5200, 2, 300_10).Debit(5300, 1, 49_95).Debit(5200, 1, 100_05).PaidFrom(1000),
+			want: []TransactionLine{
+				{Amount: 300_10, Account: Account{AccountNumber: 5200}, Fund: Fund{ID: 2}},
+				{Amount: 49_95, Account: Account{AccountNumber: 5300}, Fund: Fund{ID: 1}},
+				{Amount: 100_05, Account: Account{AccountNumber: 5200}, Fund: Fund{ID: 1}},
+				{Amount: -300_10, Account: Account{AccountNumber: 1000}, Fund: Fund{ID: 2}},
+				{Amount: -150_00, Account: Account{AccountNumber: 1000}, Fund: Fund{ID: 1}},
+			},
+		},
+		{
+			desc:  "deposit split across income accounts",
+			entry: NewDeposit(date, "Event").Credit(4000, 1, 500_00).Credit(4100, 1, 250_50).DepositedTo(1000),
+			want: []TransactionLine{
+				{Amount: -500_00, Account: Account{AccountNumber: 4000}, Fund: Fund{ID: 1}},
+				{Amount: -250_50, Account: Account{AccountNumber: 4100}, Fund: Fund{ID: 1}},
+				{Amount: 750_50, Account: Account{AccountNumber: 1000}, Fund: Fund{ID: 1}},
+			},
+		},
+		{
+			desc:  "already balanced fund",
+			entry: NewExpense(date, "Reclass").Debit(5200, 1, 10_00).Credit(5300, 1, 10_00).Debit(5200, 2, 5_00).PaidFrom(1000),
+			want: []TransactionLine{
+				{Amount: 10_00, Account: Account{AccountNumber: 5200}, Fund: Fund{ID: 1}},
+				{Amount: -10_00, Account: Account{AccountNumber: 5300}, Fund: Fund{ID: 1}},
+				{Amount: 5_00, Account: Account{AccountNumber: 5200}, Fund: Fund{ID: 2}},
+				{Amount: -5_00, Account: Account{AccountNumber: 1000}, Fund: Fund{ID: 2}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := test.entry.Build()
+			if err != nil {
+				t.Fatalf("Build: %v", err)
+			}
+			if !reflect.DeepEqual(got.Lines, test.want) {
+				t.Errorf("Build() lines = %+v, want %+v", got.Lines, test.want)
+			}
+		})
+	}
+}
+
+func TestJournalEntryErrors(t *testing.T) {
+	date := d(2023, time.June, 1)
+	tests := []struct {
+		desc           string
+		entry          *JournalEntry
+		wantUnbalanced *UnbalancedError
+	}{
+		{
+			desc:           "unbalanced",
+			entry:          NewJournalEntry(date, "").Debit(5100, 1, 10_01).Credit(1000, 1, 10_00),
+			wantUnbalanced: &UnbalancedError{Debits: 10_01, Credits: 10_00},
+		},
+		{
+			desc:  "negative amount",
+			entry: NewJournalEntry(date, "").Debit(5100, 1, -10_00).Credit(1000, 1, -10_00),
+		},
+		{
+			desc:  "no fund",
+			entry: NewJournalEntry(date, "").Debit(5100, 0, 10_00).Credit(1000, 1, 10_00),
+		},
+		{
+			desc:  "no account",
+			entry: NewJournalEntry(date, "").Debit(5100, 1, 10_00).Credit(0, 1, 10_00),
+		},
+		{
+			desc:  "no date",
+			entry: NewJournalEntry(Date{}, "").Debit(5100, 1, 10_00).Credit(1000, 1, 10_00),
+		},
+		{
+			desc:  "single line",
+			entry: NewJournalEntry(date, "").Debit(5100, 1, 10_00),
+		},
+		{
+			desc:  "expense with net credits",
+			entry: NewExpense(date, "").Debit(5200, 1, 10_00).Credit(4000, 2, 5_00).PaidFrom(1000),
+		},
+		{
+			desc:  "deposit with net debits",
+			entry: NewDeposit(date, "").Credit(4000, 1, 10_00).Debit(5200, 2, 5_00).DepositedTo(1000),
+		},
+		{
+			desc:  "expense deposited",
+			entry: NewExpense(date, "").Credit(4000, 1, 10_00).DepositedTo(1000),
+		},
+		{
+			desc:  "deposit paid",
+			entry: NewDeposit(date, "").Debit(5200, 1, 10_00).PaidFrom(1000),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := test.entry.Build()
+			if err == nil {
+				t.Fatal("Build() returned no error, want one")
+			}
+			var ubErr *UnbalancedError
+			if isUnbalanced := errors.As(err, &ubErr); isUnbalanced != (test.wantUnbalanced != nil) {
+				t.Fatalf("Build() = %v, want unbalanced error %t", err, test.wantUnbalanced != nil)
+			}
+			if test.wantUnbalanced != nil && *ubErr != *test.wantUnbalanced {
+				t.Errorf("Build() = %+v, want %+v", ubErr, test.wantUnbalanced)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package aplos_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Silicon-Ally/aplos"
+	"github.com/Silicon-Ally/aplos/aplostest"
+)
+
+func TestWithLazyAuth(t *testing.T) {
+	srv := aplostest.NewServer()
+	defer srv.Close()
+	srv.Load(aplostest.SmallNonprofit())
+
+	c, err := srv.Client(aplos.WithLazyAuth())
+	if err != nil {
+		t.Fatalf("Client: %v", err)
+	}
+	if got := c.Stats().Total().Requests; got != 0 {
+		t.Errorf("made %d requests before the first call, want 0", got)
+	}
+	if _, err := c.Funds(context.Background()); err != nil {
+		t.Fatalf("Funds: %v", err)
+	}
+	if got, want := c.Stats().Endpoints["GET /auth/{client_id}"].Requests, 1; got != want {
+		t.Errorf("auth requests = %d, want %d", got, want)
+	}
+}
+
+func TestWithLazyAuthBadCredentials(t *testing.T) {
+	srv := aplostest.NewServer()
+	defer srv.Close()
+
+	other, err := aplostest.NewCredentials()
+	if err != nil {
+		t.Fatalf("NewCredentials: %v", err)
+	}
+	c, err := aplos.New(other.ClientID, srv.Credentials.Key, aplos.WithBaseURL(srv.URL), aplos.WithLazyAuth())
+	if err != nil {
+		t.Fatalf("New with an unknown client ID = %v, want no error until the first request", err)
+	}
+	if _, err := c.Funds(context.Background()); !errors.Is(err, aplos.ErrUnauthorized) {
+		t.Errorf("Funds() = %v, want ErrUnauthorized", err)
+	}
+}
@@ -0,0 +1,52 @@
+package ledger
+
+import (
+	"testing"
+
+	"github.com/Silicon-Ally/aplos"
+	"github.com/Silicon-Ally/aplos/aplostest"
+)
+
+// benchContributions generates about 48,000 undeposited contributions, and a
+// purpose map covering them. See BENCHMARKS.md for baselines.
+func benchContributions(b *testing.B) ([]aplos.Contribution, *PurposeMap) {
+	b.Helper()
+	d, err := aplostest.Generate(aplostest.GeneratorConfig{Seed: 1, Months: 24, Donors: 2000})
+	if err != nil {
+		b.Fatalf("Generate: %v", err)
+	}
+	m := &PurposeMap{ByID: make(map[int]Allocation)}
+	for _, p := range d.Purposes {
+		m.ByID[p.ID] = Allocation{FundID: p.Fund.ID, IncomeAccount: aplostest.AccountContributions}
+	}
+	contribs := d.Contributions
+	for i := range contribs {
+		contribs[i].Batch = nil
+	}
+	return contribs, m
+}
+
+func BenchmarkEntry(b *testing.B) {
+	contribs, m := benchContributions(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, c := range contribs {
+			if _, err := m.Entry(c, aplostest.AccountChecking); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkBatchDeposits(b *testing.B) {
+	contribs, m := benchContributions(b)
+	cfg := DepositConfig{BankAccount: aplostest.AccountChecking, ClearingAccount: aplostest.AccountUndeposited, Purposes: m}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := BatchDeposits(contribs, cfg); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package ledger
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Silicon-Ally/aplos"
+)
+
+// DepositConfig configures how BatchDeposits records deposits.
+type DepositConfig struct {
+	// BankAccount is the number of the account deposits are made to.
+	BankAccount int
+	// ClearingAccount is the account contributions were recorded in when they
+	// were received, like undeposited funds, which deposits move money out of.
+	// If zero, deposits credit the income account mapped to each contribution's
+	// purpose instead.
+	ClearingAccount int
+	// Purposes determines which fund each contribution is recorded in, and its
+	// income account if ClearingAccount isn't set.
+	Purposes *PurposeMap
+}
+
+// Deposit is a group of contributions deposited together, and the journal
+// entry that records it.
+type Deposit struct {
+	Date          aplos.Date
+	PaymentMethod aplos.PaymentMethod
+	Contributions []aplos.Contribution
+	Total         aplos.Amount
+	Transaction   aplos.Transaction
+}
+
+type depositKey struct {
+	date   aplos.Date
+	method aplos.PaymentMethod
+}
+
+// BatchDeposits groups the undeposited contributions, those without a
+// deposited batch, into one deposit per date and payment method, the way they'd
+// typically be taken to the bank. Deposits are ordered by date, then payment
+// method.
+//
+// Each deposit's transaction debits the bank account and credits the clearing
+// or income accounts, with lines combined per account and fund. An
+// *UnmappedPurposesError is returned if any contribution's purpose isn't in
+// the purpose map.
+func BatchDeposits(contribs []aplos.Contribution, cfg DepositConfig) ([]Deposit, error) {
+	if cfg.BankAccount == 0 {
+		return nil, fmt.Errorf("a bank account is required")
+	}
+	if cfg.Purposes == nil {
+		return nil, fmt.Errorf("a purpose map is required")
+	}
+
+	var undeposited []aplos.Contribution
+	for _, c := range contribs {
+		if c.Batch != nil && c.Batch.Deposited {
+			continue
+		}
+		undeposited = append(undeposited, c)
+	}
+	if err := cfg.Purposes.Validate(undeposited); err != nil {
+		return nil, err
+	}
+
+	groups := make(map[depositKey]*Deposit)
+	var keys []depositKey
+	for _, c := range undeposited {
+		k := depositKey{date: c.Date, method: c.PaymentMethod}
+		dep, ok := groups[k]
+		if !ok {
+			dep = &Deposit{Date: c.Date, PaymentMethod: c.PaymentMethod}
+			groups[k] = dep
+			keys = append(keys, k)
+		}
+		dep.Contributions = append(dep.Contributions, c)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i].date, keys[j].date
+		if a != b {
+			return a.Before(b)
+		}
+		return keys[i].method < keys[j].method
+	})
+
+	out := make([]Deposit, 0, len(keys))
+	for _, k := range keys {
+		dep := groups[k]
+		txn, total, err := cfg.depositEntry(dep)
+		if err != nil {
+			return nil, err
+		}
+		dep.Transaction, dep.Total = txn, total
+		out = append(out, *dep)
+	}
+	return out, nil
+}
+
+type lineKey struct {
+	account, fund int
+}
+
+func (cfg *DepositConfig) depositEntry(dep *Deposit) (aplos.Transaction, aplos.Amount, error) {
+	sums := make(map[lineKey]aplos.Amount)
+	var order []lineKey
+	var total aplos.Amount
+	for _, c := range dep.Contributions {
+		lines, err := cfg.Purposes.lines(c, cfg.BankAccount)
+		if err != nil {
+			return aplos.Transaction{}, 0, fmt.Errorf("contribution %d: %w", c.ID, err)
+		}
+		for _, l := range lines {
+			acct := l.Account.AccountNumber
+			if l.Amount < 0 && cfg.ClearingAccount != 0 {
+				acct = cfg.ClearingAccount
+			}
+			k := lineKey{account: acct, fund: l.Fund.ID}
+			if _, ok := sums[k]; !ok {
+				order = append(order, k)
+			}
+			sums[k] += l.Amount
+			if l.Amount > 0 {
+				total += l.Amount
+			}
+		}
+	}
+
+	txn := aplos.Transaction{
+		Date: dep.Date,
+		Memo: cfg.Purposes.Memos.deposit(dep, total),
+	}
+	for _, k := range order {
+		if sums[k] == 0 {
+			continue
+		}
+		txn.Lines = append(txn.Lines, aplos.TransactionLine{
+			Amount:  sums[k],
+			Account: aplos.Account{AccountNumber: k.account},
+			Fund:    aplos.Fund{ID: k.fund},
+		})
+	}
+	return txn, total, nil
+}
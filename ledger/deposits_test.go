@@ -0,0 +1,95 @@
+package ledger
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Silicon-Ally/aplos"
+)
+
+func TestBatchDeposits(t *testing.T) {
+	m := loadPurposeMap(t)
+	may7 := aplos.Date{Year: 2023, Month: time.May, Day: 7}
+	may14 := aplos.Date{Year: 2023, Month: time.May, Day: 14}
+	annual := aplos.Purpose{ID: 3}
+	building := aplos.Purpose{Name: "Building Campaign"}
+
+	contribs := []aplos.Contribution{
+		{ID: 1, Date: may14, Amount: 20_00, PaymentMethod: aplos.PaymentMethodCash, Purpose: annual},
+		{ID: 2, Date: may7, Amount: 100_10, PaymentMethod: aplos.PaymentMethodCheck, Purpose: annual},
+		{ID: 3, Date: may7, Amount: 50_00, PaymentMethod: aplos.PaymentMethodCheck, Purpose: building},
+		{ID: 4, Date: may7, Amount: 25_20, PaymentMethod: aplos.PaymentMethodCheck, Purpose: annual},
+		{ID: 5, Date: may7, Amount: 10_00, PaymentMethod: aplos.PaymentMethodCash, Purpose: annual},
+		// Already deposited, so skipped.
+		{ID: 6, Date: may7, Amount: 99_00, PaymentMethod: aplos.PaymentMethodCheck, Purpose: annual, Batch: &aplos.ContributionBatch{ID: 1, Deposited: true}},
+	}
+
+	got, err := BatchDeposits(contribs, DepositConfig{BankAccount: 1000, ClearingAccount: 1050, Purposes: m})
+	if err != nil {
+		t.Fatalf("BatchDeposits: %v", err)
+	}
+
+	type summary struct {
+		date   aplos.Date
+		method aplos.PaymentMethod
+		ids    []int
+		total  aplos.Amount
+		memo   string
+		nLines int
+	}
+	var sums []summary
+	for _, dep := range got {
+		s := summary{date: dep.Date, method: dep.PaymentMethod, total: dep.Total, memo: dep.Transaction.Memo, nLines: len(dep.Transaction.Lines)}
+		for _, c := range dep.Contributions {
+			s.ids = append(s.ids, c.ID)
+		}
+		sums = append(sums, s)
+	}
+	want := []summary{
+		{date: may7, method: aplos.PaymentMethodCash, ids: []int{5}, total: 10_00, memo: "Deposit of 1 cash contribution", nLines: 2},
+		{date: may7, method: aplos.PaymentMethodCheck, ids: []int{2, 3, 4}, total: 175_30, memo: "Deposit of 3 check contributions", nLines: 4},
+		{date: may14, method: aplos.PaymentMethodCash, ids: []int{1}, total: 20_00, memo: "Deposit of 1 cash contribution", nLines: 2},
+	}
+	if !reflect.DeepEqual(sums, want) {
+		t.Fatalf("BatchDeposits() = %+v, want %+v", sums, want)
+	}
+
+	wantLines := []aplos.TransactionLine{
+		{Amount: 125_30, Account: aplos.Account{AccountNumber: 1000}, Fund: aplos.Fund{ID: 1}},
+		{Amount: -125_30, Account: aplos.Account{AccountNumber: 1050}, Fund: aplos.Fund{ID: 1}},
+		{Amount: 50_00, Account: aplos.Account{AccountNumber: 1000}, Fund: aplos.Fund{ID: 2}},
+		{Amount: -50_00, Account: aplos.Account{AccountNumber: 1050}, Fund: aplos.Fund{ID: 2}},
+	}
+	if !reflect.DeepEqual(got[1].Transaction.Lines, wantLines) {
+		t.Errorf("check deposit lines = %+v, want %+v", got[1].Transaction.Lines, wantLines)
+	}
+}
+
+func TestBatchDepositsToIncome(t *testing.T) {
+	m := loadPurposeMap(t)
+	contribs := []aplos.Contribution{
+		{ID: 1, Amount: 40_00, PaymentMethod: aplos.PaymentMethodCash, Purpose: aplos.Purpose{ID: 3}},
+	}
+	got, err := BatchDeposits(contribs, DepositConfig{BankAccount: 1000, Purposes: m})
+	if err != nil {
+		t.Fatalf("BatchDeposits: %v", err)
+	}
+	want := []aplos.TransactionLine{
+		{Amount: 40_00, Account: aplos.Account{AccountNumber: 1000}, Fund: aplos.Fund{ID: 1}},
+		{Amount: -40_00, Account: aplos.Account{AccountNumber: 4000}, Fund: aplos.Fund{ID: 1}},
+	}
+	if len(got) != 1 || !reflect.DeepEqual(got[0].Transaction.Lines, want) {
+		t.Errorf("BatchDeposits() = %+v, want one deposit with lines %+v", got, want)
+	}
+}
+
+func TestBatchDepositsUnmapped(t *testing.T) {
+	contribs := []aplos.Contribution{{ID: 1, Amount: 40_00, Purpose: aplos.Purpose{ID: 10}}}
+	_, err := BatchDeposits(contribs, DepositConfig{BankAccount: 1000, Purposes: loadPurposeMap(t)})
+	var uerr *UnmappedPurposesError
+	if !errors.As(err, &uerr) {
+		t.Errorf("BatchDeposits() = %v, want an *UnmappedPurposesError", err)
+	}
+}
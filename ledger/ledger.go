@@ -0,0 +1,186 @@
+// Package ledger converts donor records, like contributions, into the journal
+// entries that record them in the general ledger.
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Silicon-Ally/aplos"
+)
+
+// Allocation is where income for a contribution purpose is recorded.
+type Allocation struct {
+	// FundID is the fund the income is recorded in. It's ignored for
+	// contributions that are split across funds.
+	FundID int `json:"fund_id"`
+	// IncomeAccount is the number of the income account that's credited.
+	IncomeAccount int `json:"income_account"`
+}
+
+// PurposeMap maps contribution purposes to allocations. It's typically loaded
+// from a JSON config file.
+type PurposeMap struct {
+	// ByID maps purpose IDs to allocations.
+	ByID map[int]Allocation `json:"by_id"`
+	// ByName maps purpose names to allocations, for purposes that aren't in
+	// ByID. Names are matched exactly if possible, and otherwise ignoring
+	// case. Names that differ only in case must map to the same allocation,
+	// see Validate.
+	ByName map[string]Allocation `json:"by_name"`
+	// Memos formats the memos of the entries generated from the map, including
+	// deposits. Nil gives the default memos.
+	Memos *MemoFormat `json:"memos,omitempty"`
+}
+
+// Lookup returns the allocation for the given purpose, and false if the purpose
+// isn't mapped. A name that only matches ignoring case is unmapped if the
+// matching names map to different allocations.
+func (m *PurposeMap) Lookup(p aplos.Purpose) (Allocation, bool) {
+	if a, ok := m.ByID[p.ID]; ok {
+		return a, true
+	}
+	name := strings.TrimSpace(p.Name)
+	if a, ok := m.ByName[name]; ok {
+		return a, true
+	}
+	var (
+		found Allocation
+		ok    bool
+	)
+	for n, a := range m.ByName {
+		if !strings.EqualFold(strings.TrimSpace(n), name) {
+			continue
+		}
+		if ok && a != found {
+			return Allocation{}, false
+		}
+		found, ok = a, true
+	}
+	return found, ok
+}
+
+// nameCollisions returns an error describing the names in ByName that differ
+// only in case but map to different allocations, or nil if there are none.
+func (m *PurposeMap) nameCollisions() error {
+	names := make([]string, 0, len(m.ByName))
+	for n := range m.ByName {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	byKey := make(map[string]string)
+	var msgs []string
+	for _, n := range names {
+		key := strings.ToLower(strings.TrimSpace(n))
+		prev, ok := byKey[key]
+		if !ok {
+			byKey[key] = n
+			continue
+		}
+		if m.ByName[prev] != m.ByName[n] {
+			msgs = append(msgs, fmt.Sprintf("%q and %q", prev, n))
+		}
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("purpose names differ only in case but map to different allocations: %s", strings.Join(msgs, ", "))
+}
+
+// UnmappedPurposesError is returned when contributions have purposes that
+// aren't in a PurposeMap.
+type UnmappedPurposesError struct {
+	// Purposes lists the unmapped purposes, ordered by ID.
+	Purposes []aplos.Purpose
+}
+
+func (e *UnmappedPurposesError) Error() string {
+	var names []string
+	for _, p := range e.Purposes {
+		names = append(names, fmt.Sprintf("%q (ID %d)", p.Name, p.ID))
+	}
+	return fmt.Sprintf("no mapping for %d purpose(s): %s", len(e.Purposes), strings.Join(names, ", "))
+}
+
+// Validate checks that no names in ByName differ only in case while mapping to
+// different allocations, and that the purpose of every given contribution is
+// mapped, returning an *UnmappedPurposesError listing any that aren't.
+func (m *PurposeMap) Validate(contribs []aplos.Contribution) error {
+	if err := m.nameCollisions(); err != nil {
+		return err
+	}
+	seen := make(map[int]bool)
+	var unmapped []aplos.Purpose
+	for _, c := range contribs {
+		if seen[c.Purpose.ID] {
+			continue
+		}
+		seen[c.Purpose.ID] = true
+		if _, ok := m.Lookup(c.Purpose); !ok {
+			unmapped = append(unmapped, c.Purpose)
+		}
+	}
+	if len(unmapped) == 0 {
+		return nil
+	}
+	sort.Slice(unmapped, func(i, j int) bool { return unmapped[i].ID < unmapped[j].ID })
+	return &UnmappedPurposesError{Purposes: unmapped}
+}
+
+// Entry returns the journal entry recording a single contribution, which debits
+// the given asset account, e.g. undeposited funds, and credits the income
+// account mapped to the contribution's purpose. Each fund the contribution is
+// allocated to gets its own pair of lines, so the entry balances within each
+// fund.
+func (m *PurposeMap) Entry(c aplos.Contribution, debitAccount int) (aplos.Transaction, error) {
+	lines, err := m.lines(c, debitAccount)
+	if err != nil {
+		return aplos.Transaction{}, err
+	}
+	txn := aplos.Transaction{
+		Date:  c.Date,
+		Memo:  m.Memos.contribution(c),
+		Lines: lines,
+	}
+	if c.Contact.ID != 0 {
+		contact := c.Contact
+		txn.Contact = &contact
+	}
+	return txn, nil
+}
+
+func (m *PurposeMap) lines(c aplos.Contribution, debitAccount int) ([]aplos.TransactionLine, error) {
+	alloc, ok := m.Lookup(c.Purpose)
+	if !ok {
+		return nil, &UnmappedPurposesError{Purposes: []aplos.Purpose{c.Purpose}}
+	}
+
+	splits := c.Funds
+	if len(splits) == 0 {
+		if alloc.FundID == 0 {
+			return nil, fmt.Errorf("purpose %q has no fund, and contribution %d isn't split across funds", c.Purpose.Name, c.ID)
+		}
+		splits = []aplos.ContributionFund{{Fund: aplos.Fund{ID: alloc.FundID}, Amount: c.Amount}}
+	}
+
+	var lines []aplos.TransactionLine
+	for _, s := range splits {
+		if s.Fund.ID == 0 {
+			return nil, errors.New("contribution fund split has no fund ID")
+		}
+		lines = append(lines,
+			aplos.TransactionLine{Amount: s.Amount, Account: aplos.Account{AccountNumber: debitAccount}, Fund: s.Fund},
+			aplos.TransactionLine{Amount: -s.Amount, Account: aplos.Account{AccountNumber: alloc.IncomeAccount}, Fund: s.Fund},
+		)
+	}
+	return lines, nil
+}
+
+func contactName(c aplos.Contact) string {
+	if n := c.Name(); n != "" {
+		return n
+	}
+	return fmt.Sprintf("contact %d", c.ID)
+}
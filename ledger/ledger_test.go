@@ -0,0 +1,181 @@
+package ledger
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Silicon-Ally/aplos"
+)
+
+const purposeMapJSON = `{
+	"by_id": {"3": {"fund_id": 1, "income_account": 4000}},
+	"by_name": {"Building Campaign": {"fund_id": 2, "income_account": 4100}, "Scholarships": {"income_account": 4200}}
+}`
+
+func loadPurposeMap(t *testing.T) *PurposeMap {
+	t.Helper()
+	var m PurposeMap
+	if err := json.NewDecoder(strings.NewReader(purposeMapJSON)).Decode(&m); err != nil {
+		t.Fatalf("failed to decode purpose map: %v", err)
+	}
+	return &m
+}
+
+func TestLookup(t *testing.T) {
+	m := loadPurposeMap(t)
+	tests := []struct {
+		purpose aplos.Purpose
+		want    Allocation
+		wantOK  bool
+	}{
+		{purpose: aplos.Purpose{ID: 3, Name: "Annual Fund"}, want: Allocation{FundID: 1, IncomeAccount: 4000}, wantOK: true},
+		{purpose: aplos.Purpose{ID: 9, Name: "building campaign"}, want: Allocation{FundID: 2, IncomeAccount: 4100}, wantOK: true},
+		{purpose: aplos.Purpose{ID: 10, Name: "Missions"}},
+	}
+	for _, test := range tests {
+		t.Run(test.purpose.Name, func(t *testing.T) {
+			got, ok := m.Lookup(test.purpose)
+			if got != test.want || ok != test.wantOK {
+				t.Errorf("Lookup() = %+v, %t, want %+v, %t", got, ok, test.want, test.wantOK)
+			}
+		})
+	}
+}
+
+func TestLookupCaseCollision(t *testing.T) {
+	m := &PurposeMap{ByName: map[string]Allocation{
+		"Missions": {IncomeAccount: 4300},
+		"MISSIONS": {IncomeAccount: 4400},
+		"Youth":    {IncomeAccount: 4500},
+		"youth":    {IncomeAccount: 4500},
+	}}
+	tests := []struct {
+		name   string
+		want   Allocation
+		wantOK bool
+	}{
+		{name: "Missions", want: Allocation{IncomeAccount: 4300}, wantOK: true},
+		{name: "MISSIONS", want: Allocation{IncomeAccount: 4400}, wantOK: true},
+		{name: "missions"},
+		{name: "YOUTH", want: Allocation{IncomeAccount: 4500}, wantOK: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Map iteration order is random, so check more than once.
+			for range 20 {
+				got, ok := m.Lookup(aplos.Purpose{Name: test.name})
+				if got != test.want || ok != test.wantOK {
+					t.Fatalf("Lookup() = %+v, %t, want %+v, %t", got, ok, test.want, test.wantOK)
+				}
+			}
+		})
+	}
+
+	err := m.Validate(nil)
+	if err == nil || !strings.Contains(err.Error(), `"MISSIONS" and "Missions"`) {
+		t.Errorf("Validate() = %v, want a case collision error for Missions", err)
+	}
+	if err != nil && strings.Contains(err.Error(), "outh") {
+		t.Errorf("Validate() = %v, want no error for Youth, which maps to the same allocation", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	m := loadPurposeMap(t)
+	contribs := []aplos.Contribution{
+		{ID: 1, Purpose: aplos.Purpose{ID: 3}},
+		{ID: 2, Purpose: aplos.Purpose{ID: 11, Name: "Youth"}},
+		{ID: 3, Purpose: aplos.Purpose{ID: 10, Name: "Missions"}},
+		{ID: 4, Purpose: aplos.Purpose{ID: 10, Name: "Missions"}},
+	}
+
+	err := m.Validate(contribs)
+	var uerr *UnmappedPurposesError
+	if !errors.As(err, &uerr) {
+		t.Fatalf("Validate() = %v, want an *UnmappedPurposesError", err)
+	}
+	want := []aplos.Purpose{{ID: 10, Name: "Missions"}, {ID: 11, Name: "Youth"}}
+	if !reflect.DeepEqual(uerr.Purposes, want) {
+		t.Errorf("unmapped purposes = %+v, want %+v", uerr.Purposes, want)
+	}
+
+	if err := m.Validate(contribs[:1]); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+}
+
+func TestEntry(t *testing.T) {
+	m := loadPurposeMap(t)
+	date := aplos.Date{Year: 2023, Month: time.May, Day: 7}
+	const undeposited = 1050
+
+	t.Run("single fund", func(t *testing.T) {
+		c := aplos.Contribution{
+			ID:      1,
+			Date:    date,
+			Amount:  50_00,
+			Contact: aplos.Contact{ID: 7, Type: aplos.ContactTypeIndividual, FirstName: "Grace", LastName: "Hopper"},
+			Purpose: aplos.Purpose{ID: 3},
+		}
+		got, err := m.Entry(c, undeposited)
+		if err != nil {
+			t.Fatalf("Entry: %v", err)
+		}
+		want := aplos.Transaction{
+			Date:    date,
+			Memo:    "Contribution from Grace Hopper",
+			Contact: &c.Contact,
+			Lines: []aplos.TransactionLine{
+				{Amount: 50_00, Account: aplos.Account{AccountNumber: undeposited}, Fund: aplos.Fund{ID: 1}},
+				{Amount: -50_00, Account: aplos.Account{AccountNumber: 4000}, Fund: aplos.Fund{ID: 1}},
+			},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Entry() = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("split across funds", func(t *testing.T) {
+		c := aplos.Contribution{
+			Date:    date,
+			Amount:  100_00,
+			Purpose: aplos.Purpose{Name: "Scholarships"},
+			Funds: []aplos.ContributionFund{
+				{Fund: aplos.Fund{ID: 1}, Amount: 70_00},
+				{Fund: aplos.Fund{ID: 4}, Amount: 30_00},
+			},
+		}
+		got, err := m.Entry(c, undeposited)
+		if err != nil {
+			t.Fatalf("Entry: %v", err)
+		}
+		want := []aplos.TransactionLine{
+			{Amount: 70_00, Account: aplos.Account{AccountNumber: undeposited}, Fund: aplos.Fund{ID: 1}},
+			{Amount: -70_00, Account: aplos.Account{AccountNumber: 4200}, Fund: aplos.Fund{ID: 1}},
+			{Amount: 30_00, Account: aplos.Account{AccountNumber: undeposited}, Fund: aplos.Fund{ID: 4}},
+			{Amount: -30_00, Account: aplos.Account{AccountNumber: 4200}, Fund: aplos.Fund{ID: 4}},
+		}
+		if !reflect.DeepEqual(got.Lines, want) {
+			t.Errorf("Entry().Lines = %+v, want %+v", got.Lines, want)
+		}
+	})
+
+	t.Run("no fund", func(t *testing.T) {
+		c := aplos.Contribution{Amount: 10_00, Purpose: aplos.Purpose{Name: "Scholarships"}}
+		if _, err := m.Entry(c, undeposited); err == nil {
+			t.Error("Entry() returned no error, want one")
+		}
+	})
+
+	t.Run("unmapped", func(t *testing.T) {
+		c := aplos.Contribution{Amount: 10_00, Purpose: aplos.Purpose{ID: 10}}
+		var uerr *UnmappedPurposesError
+		if _, err := m.Entry(c, undeposited); !errors.As(err, &uerr) {
+			t.Errorf("Entry() = %v, want an *UnmappedPurposesError", err)
+		}
+	})
+}
@@ -0,0 +1,141 @@
+package ledger
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Silicon-Ally/aplos"
+)
+
+// Default memo templates, see MemoFormat.
+const (
+	DefaultContributionMemo = "Contribution from {contact}"
+	DefaultDepositMemo      = "Deposit of {count} {method} {contributions}"
+)
+
+// MemoFormat controls the memos of generated entries, so that automated
+// entries are uniformly identifiable in the ledger, e.g. by searching for a
+// prefix with aplos.WithMemoContains. It's typically loaded from a JSON config file,
+// as part of a PurposeMap. The zero value gives the default memos.
+//
+// Templates substitute variables written like {contact}. Contribution memos
+// have {contact}, {purpose}, {date}, {amount}, and {id}; deposit memos have
+// {count}, {method}, {contributions} ("contribution" or "contributions",
+// depending on the count), {date}, and {total}. Unknown variables are left
+// as is.
+type MemoFormat struct {
+	// Prefix is prepended to every memo, e.g. "[donor-sync] ".
+	Prefix string `json:"prefix"`
+	// MaxLength caps the length of memos, including the prefix, in characters.
+	// Longer memos are truncated and end in "...", or are just cut off if
+	// MaxLength is too short to fit the ellipsis. Zero means no limit.
+	MaxLength int `json:"max_length"`
+	// Contribution is the template for contribution entries, and defaults to
+	// DefaultContributionMemo.
+	Contribution string `json:"contribution"`
+	// Deposit is the template for deposit entries, and defaults to
+	// DefaultDepositMemo.
+	Deposit string `json:"deposit"`
+}
+
+var (
+	memoVar   = regexp.MustCompile(`\{([a-z_]+)\}`)
+	memoSpace = regexp.MustCompile(`\s+`)
+)
+
+// Format expands the variables in template, normalizes whitespace, adds the
+// prefix, and enforces the maximum length. A nil *MemoFormat just expands
+// and normalizes.
+func (f *MemoFormat) Format(template string, vars map[string]string) string {
+	memo := memoVar.ReplaceAllStringFunc(template, func(m string) string {
+		if v, ok := vars[m[1:len(m)-1]]; ok {
+			return v
+		}
+		return m
+	})
+	memo = strings.TrimSpace(memoSpace.ReplaceAllString(memo, " "))
+	if f == nil {
+		return memo
+	}
+	return truncate(f.Prefix+memo, f.MaxLength)
+}
+
+// Append adds suffix, e.g. an importer's batch token, to the end of an
+// existing memo, normalizing whitespace and adding the prefix if the memo
+// doesn't already start with it. The suffix is never truncated, so it can be
+// relied on to find the entry again; room is made for it within MaxLength by
+// truncating the rest of the memo. A nil *MemoFormat just normalizes and
+// appends.
+func (f *MemoFormat) Append(memo, suffix string) string {
+	memo = strings.TrimSpace(memoSpace.ReplaceAllString(memo, " "))
+	if f == nil {
+		return strings.TrimSpace(memo + " " + suffix)
+	}
+	if !strings.HasPrefix(memo, f.Prefix) {
+		memo = f.Prefix + memo
+	}
+	memo = strings.TrimSpace(memo)
+	if memo == "" {
+		return suffix
+	}
+	if f.MaxLength > 0 {
+		room := f.MaxLength - utf8.RuneCountInString(suffix) - 1
+		if room <= 0 {
+			return suffix
+		}
+		memo = truncate(memo, room)
+	}
+	return memo + " " + suffix
+}
+
+// truncate shortens memo to at most n characters, ending in "..." if there's
+// room for it. Zero means no limit.
+func truncate(memo string, n int) string {
+	if n <= 0 || utf8.RuneCountInString(memo) <= n {
+		return memo
+	}
+	const ellipsis = "..."
+	if n <= len(ellipsis) {
+		return string([]rune(memo)[:n])
+	}
+	keep := n - len(ellipsis)
+	return strings.TrimRight(string([]rune(memo)[:keep]), " ") + ellipsis
+}
+
+func (f *MemoFormat) contribution(c aplos.Contribution) string {
+	template := DefaultContributionMemo
+	if f != nil && f.Contribution != "" {
+		template = f.Contribution
+	}
+	return f.Format(template, map[string]string{
+		"contact": contactName(c.Contact),
+		"purpose": c.Purpose.Name,
+		"date":    c.Date.String(),
+		"amount":  c.Amount.String(),
+		"id":      strconv.Itoa(c.ID),
+	})
+}
+
+func (f *MemoFormat) deposit(dep *Deposit, total aplos.Amount) string {
+	template := DefaultDepositMemo
+	if f != nil && f.Deposit != "" {
+		template = f.Deposit
+	}
+	method := string(dep.PaymentMethod)
+	if method == "" {
+		method = "unspecified"
+	}
+	noun := "contributions"
+	if len(dep.Contributions) == 1 {
+		noun = "contribution"
+	}
+	return f.Format(template, map[string]string{
+		"count":         strconv.Itoa(len(dep.Contributions)),
+		"method":        method,
+		"contributions": noun,
+		"date":          dep.Date.String(),
+		"total":         total.String(),
+	})
+}
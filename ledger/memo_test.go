@@ -0,0 +1,79 @@
+package ledger
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Silicon-Ally/aplos"
+)
+
+func TestMemoFormat(t *testing.T) {
+	vars := map[string]string{"contact": "Grace  Hopper", "amount": "50.00"}
+	tests := []struct {
+		desc     string
+		f        *MemoFormat
+		template string
+		want     string
+	}{
+		{"nil", nil, "Gift of {amount} from {contact}", "Gift of 50.00 from Grace Hopper"},
+		{"prefix", &MemoFormat{Prefix: "[sync] "}, "From {contact}", "[sync] From Grace Hopper"},
+		{"whitespace", &MemoFormat{}, "  From\t{contact}\n{missing} ", "From Grace Hopper {missing}"},
+		{"under max length", &MemoFormat{Prefix: "[sync] ", MaxLength: 24}, "From {contact}", "[sync] From Grace Hopper"},
+		{"over max length", &MemoFormat{Prefix: "[sync] ", MaxLength: 16}, "From {contact}", "[sync] From G..."},
+		{"tiny max length", &MemoFormat{MaxLength: 2}, "From {contact}", "Fr"},
+		{"ellipsis-length max length", &MemoFormat{MaxLength: 3}, "From {contact}", "Fro"},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if got := test.f.Format(test.template, vars); got != test.want {
+				t.Errorf("Format(%q) = %q, want %q", test.template, got, test.want)
+			}
+		})
+	}
+}
+
+func TestConfiguredMemos(t *testing.T) {
+	var m PurposeMap
+	const config = `{
+		"by_id": {"3": {"fund_id": 1, "income_account": 4000}},
+		"memos": {
+			"prefix": "[donor-sync] ",
+			"max_length": 60,
+			"contribution": "Gift #{id} from {contact} on {date}",
+			"deposit": "{method} deposit, {count} gifts, {total}"
+		}
+	}`
+	if err := json.NewDecoder(strings.NewReader(config)).Decode(&m); err != nil {
+		t.Fatalf("failed to decode purpose map: %v", err)
+	}
+
+	date := aplos.Date{Year: 2023, Month: time.May, Day: 7}
+	c := aplos.Contribution{
+		ID:            12,
+		Date:          date,
+		Amount:        50_00,
+		PaymentMethod: aplos.PaymentMethodCheck,
+		Contact:       aplos.Contact{ID: 7, Type: aplos.ContactTypeIndividual, FirstName: "Grace", LastName: "Hopper"},
+		Purpose:       aplos.Purpose{ID: 3},
+	}
+	txn, err := m.Entry(c, 1050)
+	if err != nil {
+		t.Fatalf("Entry: %v", err)
+	}
+	if want := "[donor-sync] Gift #12 from Grace Hopper on " + date.String(); txn.Memo != want {
+		t.Errorf("Entry() memo = %q, want %q", txn.Memo, want)
+	}
+
+	deps, err := BatchDeposits([]aplos.Contribution{c, c}, DepositConfig{BankAccount: 1000, Purposes: &m})
+	if err != nil {
+		t.Fatalf("BatchDeposits: %v", err)
+	}
+	if len(deps) != 1 {
+		t.Fatalf("BatchDeposits() returned %d deposits, want 1", len(deps))
+	}
+	if got, want := deps[0].Transaction.Memo, "[donor-sync] check deposit, 2 gifts, 100.00"; got != want {
+		t.Errorf("deposit memo = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,365 @@
+// Package lint scans Aplos data for common data quality issues, like
+// transaction lines without a fund, entries dated in the future, references to
+// accounts, funds, and purposes that have been deleted or disabled, or contacts
+// without a name or sharing an email address.
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Silicon-Ally/aplos"
+)
+
+// Severity indicates how serious an Issue is.
+type Severity int
+
+const (
+	Info Severity = iota
+	Warning
+	Error
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Info:
+		return "info"
+	case Warning:
+		return "warning"
+	case Error:
+		return "error"
+	default:
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+}
+
+// Kind identifies which check produced an Issue.
+type Kind string
+
+const (
+	MissingFund     Kind = "missing_fund"
+	ZeroAmountLine  Kind = "zero_amount_line"
+	FutureDated     Kind = "future_dated"
+	MissingMemo     Kind = "missing_memo"
+	OrphanedAccount Kind = "orphaned_account"
+	OrphanedFund    Kind = "orphaned_fund"
+	OrphanedPurpose Kind = "orphaned_purpose"
+	DisabledAccount Kind = "disabled_account"
+	DisabledFund    Kind = "disabled_fund"
+	DisabledPurpose Kind = "disabled_purpose"
+	MissingName     Kind = "missing_name"
+	DuplicateEmail  Kind = "duplicate_email"
+)
+
+// Issue is a single problem found in the data.
+type Issue struct {
+	Severity Severity
+	Kind     Kind
+	// TransactionID is the transaction the issue was found on, or zero if it
+	// was found on a contribution or contact.
+	TransactionID int
+	// ContributionID is the contribution the issue was found on, or zero if it
+	// was found on a transaction or contact.
+	ContributionID int
+	// ContactID is the contact the issue was found on, or zero if it was found
+	// on a transaction or contribution.
+	ContactID int
+	// LineID is the transaction line the issue was found on, or zero if the
+	// issue applies to the transaction as a whole.
+	LineID  int
+	Message string
+}
+
+func (i Issue) String() string {
+	if i.ContactID != 0 {
+		return fmt.Sprintf("[%s] contact %d: %s", i.Severity, i.ContactID, i.Message)
+	}
+	if i.ContributionID != 0 {
+		return fmt.Sprintf("[%s] contribution %d: %s", i.Severity, i.ContributionID, i.Message)
+	}
+	if i.LineID != 0 {
+		return fmt.Sprintf("[%s] transaction %d, line %d: %s", i.Severity, i.TransactionID, i.LineID, i.Message)
+	}
+	return fmt.Sprintf("[%s] transaction %d: %s", i.Severity, i.TransactionID, i.Message)
+}
+
+// Data is the set of records to lint. Transactions should be loaded with their
+// lines (e.g. via Client.Transaction) for the line-level checks to apply.
+type Data struct {
+	Transactions  []aplos.Transaction
+	Contributions []aplos.Contribution
+	// Accounts is the chart of accounts. If nil, the orphaned and disabled
+	// account checks are skipped.
+	Accounts []aplos.Account
+	// Funds and Purposes are all of the organization's funds and contribution
+	// purposes, including disabled ones. If either is nil, the orphaned and
+	// disabled checks for it are skipped.
+	Funds    []aplos.Fund
+	Purposes []aplos.Purpose
+	// Contacts are checked for missing names and email addresses shared by
+	// more than one contact.
+	Contacts []aplos.Contact
+}
+
+type runOpts struct {
+	now            func() time.Time
+	largeThreshold aplos.Amount
+}
+
+type Option func(*runOpts)
+
+// WithNow sets the function used to determine the current date when checking
+// for future-dated entries. Defaults to time.Now.
+func WithNow(now func() time.Time) Option {
+	return func(o *runOpts) {
+		o.now = now
+	}
+}
+
+// WithLargeAmount sets the absolute amount at or above which a transaction is
+// required to have a memo. Defaults to 1000.00.
+func WithLargeAmount(amt aplos.Amount) Option {
+	return func(o *runOpts) {
+		o.largeThreshold = amt
+	}
+}
+
+// Run checks the given data and returns all issues found, ordered from most to
+// least severe, then by transaction, contribution, contact, and line ID.
+func Run(d Data, opts ...Option) []Issue {
+	o := &runOpts{
+		now:            time.Now,
+		largeThreshold: 1000_00,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	refs := newReferences(d)
+
+	y, m, day := o.now().Date()
+	today := aplos.Date{Year: y, Month: m, Day: day}
+
+	var issues []Issue
+	for _, txn := range d.Transactions {
+		if txn.Date.After(today) {
+			issues = append(issues, Issue{
+				Severity:      Warning,
+				Kind:          FutureDated,
+				TransactionID: txn.ID,
+				Message:       fmt.Sprintf("dated %s, which is in the future", txn.Date),
+			})
+		}
+		if txn.Amount.Abs() >= o.largeThreshold && strings.TrimSpace(txn.Memo) == "" {
+			issues = append(issues, Issue{
+				Severity:      Info,
+				Kind:          MissingMemo,
+				TransactionID: txn.ID,
+				Message:       fmt.Sprintf("amount %s has no memo", txn.Amount),
+			})
+		}
+		for _, l := range txn.Lines {
+			if l.Fund.ID == 0 {
+				issues = append(issues, Issue{
+					Severity:      Error,
+					Kind:          MissingFund,
+					TransactionID: txn.ID,
+					LineID:        l.ID,
+					Message:       "line has no fund",
+				})
+			}
+			if l.Amount == 0 {
+				issues = append(issues, Issue{
+					Severity:      Warning,
+					Kind:          ZeroAmountLine,
+					TransactionID: txn.ID,
+					LineID:        l.ID,
+					Message:       "line has a zero amount",
+				})
+			}
+			issues = append(issues, refs.line(txn.ID, l)...)
+		}
+	}
+	for _, c := range d.Contributions {
+		issues = append(issues, refs.contribution(c)...)
+	}
+	issues = append(issues, contactIssues(d.Contacts)...)
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		if a.Severity != b.Severity {
+			return a.Severity > b.Severity
+		}
+		if a.TransactionID != b.TransactionID {
+			return a.TransactionID < b.TransactionID
+		}
+		if a.ContributionID != b.ContributionID {
+			return a.ContributionID < b.ContributionID
+		}
+		if a.ContactID != b.ContactID {
+			return a.ContactID < b.ContactID
+		}
+		return a.LineID < b.LineID
+	})
+
+	return issues
+}
+
+// references indexes the accounts, funds, and purposes that records can refer
+// to. A nil map means the corresponding records weren't given, so references
+// to them aren't checked.
+type references struct {
+	accounts map[int]aplos.Account
+	funds    map[int]aplos.Fund
+	purposes map[int]aplos.Purpose
+}
+
+func newReferences(d Data) *references {
+	r := &references{}
+	if d.Accounts != nil {
+		r.accounts = make(map[int]aplos.Account)
+		for _, a := range d.Accounts {
+			r.accounts[a.AccountNumber] = a
+		}
+	}
+	if d.Funds != nil {
+		r.funds = make(map[int]aplos.Fund)
+		for _, f := range d.Funds {
+			r.funds[f.ID] = f
+		}
+	}
+	if d.Purposes != nil {
+		r.purposes = make(map[int]aplos.Purpose)
+		for _, p := range d.Purposes {
+			r.purposes[p.ID] = p
+		}
+	}
+	return r
+}
+
+// line checks the account and fund referenced by a transaction line. Lines
+// without a fund are reported separately, as MissingFund.
+func (r *references) line(txnID int, l aplos.TransactionLine) []Issue {
+	var issues []Issue
+	add := func(sev Severity, kind Kind, msg string, args ...any) {
+		issues = append(issues, Issue{
+			Severity:      sev,
+			Kind:          kind,
+			TransactionID: txnID,
+			LineID:        l.ID,
+			Message:       fmt.Sprintf(msg, args...),
+		})
+	}
+	if r.accounts != nil {
+		num := l.Account.AccountNumber
+		if a, ok := r.accounts[num]; !ok {
+			add(Error, OrphanedAccount, "line references account %d, which is not in the chart of accounts", num)
+		} else if !a.IsEnabled {
+			add(Warning, DisabledAccount, "line references account %d %q, which is disabled", num, a.Name)
+		}
+	}
+	if r.funds != nil && l.Fund.ID != 0 {
+		if f, ok := r.funds[l.Fund.ID]; !ok {
+			add(Error, OrphanedFund, "line references fund %d, which doesn't exist", l.Fund.ID)
+		} else if !f.IsEnabled {
+			add(Warning, DisabledFund, "line references fund %d %q, which is disabled", f.ID, f.Name)
+		}
+	}
+	return issues
+}
+
+// contribution checks the purpose and funds referenced by a contribution,
+// including the purpose's default fund if the contribution isn't split across
+// funds.
+func (r *references) contribution(c aplos.Contribution) []Issue {
+	var issues []Issue
+	add := func(sev Severity, kind Kind, msg string, args ...any) {
+		issues = append(issues, Issue{
+			Severity:       sev,
+			Kind:           kind,
+			ContributionID: c.ID,
+			Message:        fmt.Sprintf(msg, args...),
+		})
+	}
+
+	funds := make([]int, 0, len(c.Funds))
+	for _, cf := range c.Funds {
+		funds = append(funds, cf.Fund.ID)
+	}
+	if r.purposes != nil && c.Purpose.ID != 0 {
+		if p, ok := r.purposes[c.Purpose.ID]; !ok {
+			add(Error, OrphanedPurpose, "contribution references purpose %d, which doesn't exist", c.Purpose.ID)
+		} else {
+			if !p.IsEnabled {
+				add(Warning, DisabledPurpose, "contribution references purpose %d %q, which is disabled", p.ID, p.Name)
+			}
+			if len(funds) == 0 && p.Fund != nil && p.Fund.ID != 0 {
+				funds = append(funds, p.Fund.ID)
+			}
+		}
+	}
+	if r.funds != nil {
+		for _, id := range funds {
+			if f, ok := r.funds[id]; !ok {
+				add(Error, OrphanedFund, "contribution references fund %d, which doesn't exist", id)
+			} else if !f.IsEnabled {
+				add(Warning, DisabledFund, "contribution references fund %d %q, which is disabled", f.ID, f.Name)
+			}
+		}
+	}
+	return issues
+}
+
+// contactIssues checks for contacts without a name, and for email addresses
+// shared by more than one contact, which usually means the same person was
+// entered twice. Each shared address is reported on every contact but the one
+// with the lowest ID. Addresses are compared ignoring case.
+func contactIssues(contacts []aplos.Contact) []Issue {
+	var issues []Issue
+	owners := make(map[string][]int)
+	var emails []string
+	for _, c := range contacts {
+		if c.Name() == "" {
+			issues = append(issues, Issue{
+				Severity:  Warning,
+				Kind:      MissingName,
+				ContactID: c.ID,
+				Message:   "contact has no name",
+			})
+		}
+		seen := make(map[string]bool)
+		addrs := []string{c.Email}
+		for _, e := range c.Emails {
+			addrs = append(addrs, e.Address)
+		}
+		for _, a := range addrs {
+			a = strings.ToLower(strings.TrimSpace(a))
+			if a == "" || seen[a] {
+				continue
+			}
+			seen[a] = true
+			if _, ok := owners[a]; !ok {
+				emails = append(emails, a)
+			}
+			owners[a] = append(owners[a], c.ID)
+		}
+	}
+	for _, e := range emails {
+		ids := owners[e]
+		if len(ids) < 2 {
+			continue
+		}
+		sort.Ints(ids)
+		for _, id := range ids[1:] {
+			issues = append(issues, Issue{
+				Severity:  Warning,
+				Kind:      DuplicateEmail,
+				ContactID: id,
+				Message:   fmt.Sprintf("email %s is also used by contact %d", e, ids[0]),
+			})
+		}
+	}
+	return issues
+}
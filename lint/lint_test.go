@@ -0,0 +1,166 @@
+package lint
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Silicon-Ally/aplos"
+)
+
+func TestRun(t *testing.T) {
+	now := func() time.Time { return time.Date(2023, time.May, 1, 12, 0, 0, 0, time.UTC) }
+	accts := []aplos.Account{{AccountNumber: 1000, IsEnabled: true}, {AccountNumber: 5000, IsEnabled: true}}
+	fund := aplos.Fund{ID: 1, Name: "General"}
+
+	tests := []struct {
+		desc string
+		in   Data
+		want []Issue
+	}{
+		{
+			desc: "clean",
+			in: Data{
+				Accounts: accts,
+				Transactions: []aplos.Transaction{
+					{
+						ID:     1,
+						Memo:   "Rent",
+						Date:   aplos.Date{Year: 2023, Month: time.May, Day: 1},
+						Amount: 1500_00,
+						Lines: []aplos.TransactionLine{
+							{ID: 10, Amount: 1500_00, Account: aplos.Account{AccountNumber: 5000}, Fund: fund},
+							{ID: 11, Amount: -1500_00, Account: aplos.Account{AccountNumber: 1000}, Fund: fund},
+						},
+					},
+				},
+			},
+			want: nil,
+		},
+		{
+			desc: "all issues",
+			in: Data{
+				Accounts: accts,
+				Transactions: []aplos.Transaction{
+					{
+						ID:     2,
+						Date:   aplos.Date{Year: 2023, Month: time.May, Day: 2},
+						Amount: 1000_00,
+						Lines: []aplos.TransactionLine{
+							{ID: 20, Amount: 0, Account: aplos.Account{AccountNumber: 5000}, Fund: fund},
+							{ID: 21, Amount: 1000_00, Account: aplos.Account{AccountNumber: 9999}},
+						},
+					},
+				},
+			},
+			want: []Issue{
+				{Severity: Error, Kind: MissingFund, TransactionID: 2, LineID: 21, Message: "line has no fund"},
+				{Severity: Error, Kind: OrphanedAccount, TransactionID: 2, LineID: 21, Message: "line references account 9999, which is not in the chart of accounts"},
+				{Severity: Warning, Kind: FutureDated, TransactionID: 2, Message: "dated 2023-05-02, which is in the future"},
+				{Severity: Warning, Kind: ZeroAmountLine, TransactionID: 2, LineID: 20, Message: "line has a zero amount"},
+				{Severity: Info, Kind: MissingMemo, TransactionID: 2, Message: "amount 1000.00 has no memo"},
+			},
+		},
+		{
+			desc: "no chart of accounts",
+			in: Data{
+				Transactions: []aplos.Transaction{
+					{
+						ID:     3,
+						Memo:   "Coffee",
+						Date:   aplos.Date{Year: 2023, Month: time.April, Day: 30},
+						Amount: 5_00,
+						Lines: []aplos.TransactionLine{
+							{ID: 30, Amount: 5_00, Account: aplos.Account{AccountNumber: 9999}, Fund: fund},
+						},
+					},
+				},
+			},
+			want: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got := Run(test.in, WithNow(now))
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Run() = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestReferences(t *testing.T) {
+	now := func() time.Time { return time.Date(2023, time.May, 1, 12, 0, 0, 0, time.UTC) }
+	date := aplos.Date{Year: 2023, Month: time.April, Day: 1}
+	general := aplos.Fund{ID: 1, Name: "General", IsEnabled: true}
+	oldGrant := aplos.Fund{ID: 2, Name: "Old Grant"}
+	d := Data{
+		Accounts: []aplos.Account{
+			{AccountNumber: 1000, Name: "Checking", IsEnabled: true},
+			{AccountNumber: 5100, Name: "Old Expenses"},
+		},
+		Funds: []aplos.Fund{general, oldGrant},
+		Purposes: []aplos.Purpose{
+			{ID: 3, Name: "Annual Fund", IsEnabled: true, Fund: &general},
+			{ID: 4, Name: "Gala 2019", Fund: &oldGrant},
+		},
+		Transactions: []aplos.Transaction{
+			{
+				ID:   1,
+				Memo: "Supplies",
+				Date: date,
+				Lines: []aplos.TransactionLine{
+					{ID: 10, Amount: 20_00, Account: aplos.Account{AccountNumber: 5100}, Fund: aplos.Fund{ID: 2}},
+					{ID: 11, Amount: -20_00, Account: aplos.Account{AccountNumber: 1000}, Fund: aplos.Fund{ID: 9}},
+				},
+			},
+		},
+		Contributions: []aplos.Contribution{
+			{ID: 20, Date: date, Amount: 50_00, Purpose: aplos.Purpose{ID: 3}},
+			{ID: 21, Date: date, Amount: 50_00, Purpose: aplos.Purpose{ID: 4}},
+			{ID: 22, Date: date, Amount: 50_00, Purpose: aplos.Purpose{ID: 7}, Funds: []aplos.ContributionFund{{Fund: aplos.Fund{ID: 1}, Amount: 50_00}}},
+		},
+	}
+
+	got := Run(d, WithNow(now))
+	want := []Issue{
+		{Severity: Error, Kind: OrphanedPurpose, ContributionID: 22, Message: "contribution references purpose 7, which doesn't exist"},
+		{Severity: Error, Kind: OrphanedFund, TransactionID: 1, LineID: 11, Message: "line references fund 9, which doesn't exist"},
+		{Severity: Warning, Kind: DisabledPurpose, ContributionID: 21, Message: `contribution references purpose 4 "Gala 2019", which is disabled`},
+		{Severity: Warning, Kind: DisabledFund, ContributionID: 21, Message: `contribution references fund 2 "Old Grant", which is disabled`},
+		{Severity: Warning, Kind: DisabledAccount, TransactionID: 1, LineID: 10, Message: `line references account 5100 "Old Expenses", which is disabled`},
+		{Severity: Warning, Kind: DisabledFund, TransactionID: 1, LineID: 10, Message: `line references fund 2 "Old Grant", which is disabled`},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Run() = %+v, want %+v", got, want)
+	}
+
+	if got, want := got[0].String(), "[error] contribution 22: contribution references purpose 7, which doesn't exist"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestContacts(t *testing.T) {
+	d := Data{
+		Contacts: []aplos.Contact{
+			{ID: 1, Type: aplos.ContactTypeIndividual, FirstName: "Grace", LastName: "Hopper", Email: "grace@example.com"},
+			{ID: 2, Type: aplos.ContactTypeIndividual, FirstName: "G.", LastName: "Hopper", Emails: []aplos.ContactEmail{{Address: "Grace@Example.com "}}},
+			{ID: 3, Type: aplos.ContactTypeCompany, CompanyName: "Acme", Email: "billing@acme.example", Emails: []aplos.ContactEmail{{Address: "billing@acme.example", IsPrimary: true}}},
+			{ID: 4, Type: aplos.ContactTypeIndividual},
+		},
+	}
+
+	got := Run(d)
+	want := []Issue{
+		{Severity: Warning, Kind: DuplicateEmail, ContactID: 2, Message: "email grace@example.com is also used by contact 1"},
+		{Severity: Warning, Kind: MissingName, ContactID: 4, Message: "contact has no name"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Run() = %+v, want %+v", got, want)
+	}
+
+	if got, want := got[1].String(), "[warning] contact 4: contact has no name"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,61 @@
+package aplos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// Organization is a nonprofit managed by an Aplos partner account.
+type Organization struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type listOrganizationsResponse struct {
+	Version string
+	Status  int
+	listPage
+	Data listOrganizationsResponseData
+}
+
+type listOrganizationsResponseData struct {
+	Organizations []Organization
+}
+
+// Organizations returns the organizations managed by the partner account the
+// client's API key belongs to. All pages of results are loaded. For other API
+// keys, the API returns an error.
+func (c *Client) Organizations(ctx context.Context) ([]Organization, error) {
+	var orgs []Organization
+	err := forEachPage(ctx, c, c.url("/partners/organizations", url.Values{}), func(r *listOrganizationsResponse) bool {
+		if orgs == nil {
+			orgs = make([]Organization, 0, r.Meta.RecordCount)
+		}
+		orgs = append(orgs, r.Data.Organizations...)
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	return orgs, nil
+}
+
+// ForOrganization returns a client that acts on behalf of the given
+// organization, like one created with WithOrganizationID, with the same
+// credentials and options as c. The returned client authenticates separately,
+// but shares c's rate limits and Stats, since they're all drawing on the same
+// API key. Responses cached for different organizations are kept separate.
+func (c *Client) ForOrganization(id int) (*Client, error) {
+	if c.opts == nil {
+		return nil, errors.New("client wasn't created with New")
+	}
+	o := *c.opts
+	o.organizationID = id
+	oc, err := newClient(c.clientID, c.key, &o, c.capabilities, c.shared)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for organization %d: %w", id, err)
+	}
+	return oc, nil
+}
@@ -0,0 +1,64 @@
+package aplos
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestOrganizations(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/partners/organizations" {
+			t.Errorf("request path = %q, want /partners/organizations", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"status": 200, "data": {"organizations": [{"id": 11, "name": "Food Bank"}, {"id": 12, "name": "Animal Shelter"}]}}`)
+	}))
+
+	got, err := c.Organizations(context.Background())
+	if err != nil {
+		t.Fatalf("Organizations: %v", err)
+	}
+	want := []Organization{{ID: 11, Name: "Food Bank"}, {ID: 12, Name: "Animal Shelter"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Organizations() = %+v, want %+v", got, want)
+	}
+
+	if _, err := c.ForOrganization(11); err == nil {
+		t.Error("ForOrganization on a client not created with New returned no error, want one")
+	}
+}
+
+func TestAuthURL(t *testing.T) {
+	if got, want := (&ts{baseURL: defaultBaseURL, clientID: "abc"}).authURL(), defaultBaseURL+"/auth/abc"; got != want {
+		t.Errorf("authURL() = %q, want %q", got, want)
+	}
+	if got, want := (&ts{baseURL: defaultBaseURL, clientID: "abc", orgID: 12}).authURL(), defaultBaseURL+"/auth/abc?organization_id=12"; got != want {
+		t.Errorf("authURL() with an organization = %q, want %q", got, want)
+	}
+}
+
+func TestCachePrefixSeparatesOrganizations(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Cache-Control", "private, max-age=60")
+		fmt.Fprint(w, `{}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	storage := &MemoryCacheStorage{}
+	for _, prefix := range []string{"org:1:", "org:2:", "org:1:"} {
+		hc := &http.Client{Transport: newCacheTransport(http.DefaultTransport, storage, prefix)}
+		resp, err := hc.Get(srv.URL + "/funds")
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		resp.Body.Close()
+	}
+	if requests != 2 {
+		t.Errorf("server got %d requests, want 2, one per organization", requests)
+	}
+}
@@ -0,0 +1,146 @@
+package aplos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ErrIncompleteList is returned when the API stops paginating before reaching
+// the last page it reported, e.g. a page short of the page count that has no
+// link to the next page. Rather than return partial results as if they were
+// complete, list calls fail with an error matching ErrIncompleteList.
+var ErrIncompleteList = errors.New("the API stopped paginating before the last page")
+
+// PageInfo describes the pagination of a list call's results, see
+// WithPageInfo.
+type PageInfo struct {
+	// Page is the number of the last page loaded, starting from 1.
+	Page int
+	// PageSize is the number of records per page.
+	PageSize int
+	// TotalRecords is the number of records matching the request across all
+	// pages, as reported by the API.
+	TotalRecords int
+	// HasNext reports whether there are more matching records than were
+	// returned, e.g. because the results were capped with WithMaxResults.
+	HasNext bool
+}
+
+// listPage holds the pagination metadata included in list responses, and is
+// embedded in each list response type.
+type listPage struct {
+	Links pageLinks
+	Meta  pageMeta
+
+	// num is the number of the page, counting from the first page loaded, for
+	// responses that don't include it in Meta.
+	num int
+}
+
+func (p *listPage) page() *listPage {
+	return p
+}
+
+// info returns the pagination info for the page, which has n records.
+func (p *listPage) info(n int) PageInfo {
+	info := PageInfo{
+		Page:         p.Meta.PageNum,
+		PageSize:     n,
+		TotalRecords: p.Meta.RecordCount,
+		HasNext:      p.Links.Next != "",
+	}
+	if info.Page <= 0 {
+		info.Page = p.num
+	}
+	// Only the last page can be short, so earlier pages give us the page size.
+	if !info.HasNext && info.Page > 1 && info.TotalRecords > n {
+		info.PageSize = (info.TotalRecords - n) / (info.Page - 1)
+	}
+	return info
+}
+
+// pageLinks are the pagination links included in list responses. Next is empty
+// on the last page.
+type pageLinks struct {
+	First string
+	Prev  string
+	Next  string
+	Last  string
+}
+
+type pageMeta struct {
+	RecordCount int `json:"record_count"`
+	PageCount   int `json:"page_count"`
+	PageNum     int `json:"page_num"`
+}
+
+// pagedResponse is a pointer to a list response type R.
+type pagedResponse[R any] interface {
+	*R
+	page() *listPage
+}
+
+// forEachPage loads the list endpoint at u and calls fn with each page of
+// results, following pagination links until there are no more pages or fn
+// returns false. If the API stops paginating short of the page count it
+// reported, an error matching ErrIncompleteList is returned.
+func forEachPage[R any, P pagedResponse[R]](ctx context.Context, c *Client, u string, fn func(P) bool) error {
+	for num := 1; ; num++ {
+		var r R
+		if err := c.getURL(ctx, u, &r); err != nil {
+			return err
+		}
+		p := P(&r)
+		p.page().num = num
+		if !fn(p) {
+			return nil
+		}
+
+		next, ok, err := nextPage(c.baseURL, u, p.page().Links.Next)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			if m := p.page().Meta; m.PageNum > 0 && m.PageNum < m.PageCount {
+				return fmt.Errorf("%w: got page %d of %d", ErrIncompleteList, m.PageNum, m.PageCount)
+			}
+			return nil
+		}
+		u = next
+	}
+}
+
+// nextPage resolves a pagination link against the current page's URL, and
+// returns false if there are no more pages. Links to a different scheme or host
+// than baseURL are an error, so the client's credentials are only ever sent to
+// the API it was configured with.
+func nextPage(baseURL, cur, next string) (string, bool, error) {
+	if next == "" {
+		return "", false, nil
+	}
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to parse base URL: %w", err)
+	}
+	curURL, err := url.Parse(cur)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to parse current page URL: %w", err)
+	}
+	ref, err := url.Parse(next)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to parse next page URL: %w", err)
+	}
+	nextURL := curURL.ResolveReference(ref)
+	if !strings.EqualFold(nextURL.Scheme, base.Scheme) || !strings.EqualFold(nextURL.Host, base.Host) {
+		return "", false, fmt.Errorf("next page URL %q is not on the API host %s://%s", next, base.Scheme, base.Host)
+	}
+	u := nextURL.String()
+	// Guard against the API pointing us back at the same page forever.
+	if u == cur {
+		return "", false, nil
+	}
+	return u, true, nil
+}
@@ -0,0 +1,111 @@
+package aplos
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"fmt"
+	"sync"
+)
+
+// ClientPool manages clients for the organizations under a partner account, for
+// integrations serving many organizations with one API key. Clients are created
+// on first use and authenticate lazily, on their first request, so a pool for
+// hundreds of organizations only runs the token flow for the ones actually
+// used. All of the pool's clients share one HTTP transport and connection pool,
+// the options the pool was created with, and one set of rate limits and Stats.
+type ClientPool struct {
+	clientID string
+	key      crypto.Decrypter
+	opts     *clientOpts
+	caps     capabilities
+	shared   *sharedState
+
+	mu      sync.Mutex
+	clients map[int]*Client
+}
+
+// NewClientPool returns a pool of clients for the organizations managed by the
+// partner account with the given credentials. Unlike New, it doesn't
+// authenticate, so invalid credentials are reported by the first request made
+// with one of the pool's clients. A WithOrganizationID option is ignored.
+func NewClientPool(clientID string, pk *rsa.PrivateKey, opts ...Option) (*ClientPool, error) {
+	return newClientPool(clientID, rsaKey(pk), opts...)
+}
+
+// NewClientPoolWithDecrypter is like NewClientPool, but authenticates with a
+// crypto.Decrypter, like a key held by a key management service, as described
+// by NewWithDecrypter.
+func NewClientPoolWithDecrypter(clientID string, key crypto.Decrypter, opts ...Option) (*ClientPool, error) {
+	if err := checkDecrypter(key); err != nil {
+		return nil, err
+	}
+	return newClientPool(clientID, key, opts...)
+}
+
+func newClientPool(clientID string, key crypto.Decrypter, opts ...Option) (*ClientPool, error) {
+	o := &clientOpts{baseURL: defaultBaseURL}
+	for _, opt := range opts {
+		opt(o)
+	}
+	o.organizationID = 0
+	o.lazyAuth = true
+
+	var caps capabilities
+	if o.allowedOperations != nil {
+		var err error
+		if caps, err = parseCapabilities(o.allowedOperations); err != nil {
+			return nil, fmt.Errorf("invalid allowed operations: %w", err)
+		}
+	}
+
+	shared, err := newSharedState(o)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ClientPool{
+		clientID: clientID,
+		key:      key,
+		opts:     o,
+		caps:     caps,
+		shared:   shared,
+		clients:  make(map[int]*Client),
+	}, nil
+}
+
+// Client returns the pool's client for the organization with the given ID,
+// creating it if needed. An ID of zero returns a client for the partner account
+// itself, e.g. to call Organizations. Clients are safe for concurrent use, and
+// the same client is returned for every call with the same ID.
+func (p *ClientPool) Client(orgID int) (*Client, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if c, ok := p.clients[orgID]; ok {
+		return c, nil
+	}
+	o := *p.opts
+	o.organizationID = orgID
+	c, err := newClient(p.clientID, p.key, &o, p.caps, p.shared)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for organization %d: %w", orgID, err)
+	}
+	p.clients[orgID] = c
+	return c, nil
+}
+
+// Organizations returns the organizations managed by the partner account, see
+// Client.Organizations.
+func (p *ClientPool) Organizations(ctx context.Context) ([]Organization, error) {
+	c, err := p.Client(0)
+	if err != nil {
+		return nil, err
+	}
+	return c.Organizations(ctx)
+}
+
+// Stats returns the combined API usage of all of the pool's clients.
+func (p *ClientPool) Stats() Stats {
+	return p.shared.stats.snapshot()
+}
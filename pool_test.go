@@ -0,0 +1,45 @@
+package aplos
+
+import (
+	"testing"
+)
+
+func TestClientPool(t *testing.T) {
+	// The pool authenticates lazily, so no key is needed until a request is
+	// made.
+	p, err := NewClientPool("client-id", nil, WithOrganizationID(3), WithAllowedOperations("transactions:read"))
+	if err != nil {
+		t.Fatalf("NewClientPool: %v", err)
+	}
+
+	c5, err := p.Client(5)
+	if err != nil {
+		t.Fatalf("Client(5): %v", err)
+	}
+	if again, _ := p.Client(5); again != c5 {
+		t.Error("Client(5) returned a different client the second time, want the same one")
+	}
+	c6, err := p.Client(6)
+	if err != nil {
+		t.Fatalf("Client(6): %v", err)
+	}
+	if c6 == c5 {
+		t.Error("Client(6) returned the same client as Client(5)")
+	}
+	if c5.opts.organizationID != 5 || c6.opts.organizationID != 6 {
+		t.Errorf("organization IDs = %d, %d, want 5, 6", c5.opts.organizationID, c6.opts.organizationID)
+	}
+	if c5.stats != c6.stats || c5.shared != c6.shared {
+		t.Error("pool clients don't share stats and rate limits")
+	}
+	if c5.capabilities == nil {
+		t.Error("pool client doesn't have the pool's allowed operations")
+	}
+	if got := p.Stats().Total().Requests; got != 0 {
+		t.Errorf("Stats().Total().Requests = %d, want 0 before any requests", got)
+	}
+
+	if _, err := NewClientPool("client-id", nil, WithAllowedOperations("bad")); err == nil {
+		t.Error("NewClientPool with an invalid operation returned no error, want one")
+	}
+}
@@ -0,0 +1,116 @@
+package aplos
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Purpose is what a contribution was given for, like a campaign or program.
+type Purpose struct {
+	ID   int    `json:"id"`
+	Name string `json:"name,omitempty"`
+
+	// Populated in Purposes and Purpose
+	IsEnabled bool `json:"is_enabled,omitempty"`
+	// Fund is the fund contributions for the purpose go to by default, if any.
+	Fund *Fund `json:"fund,omitempty"`
+}
+
+type getPurposeResponse struct {
+	Version string
+	Status  int
+	Data    getPurposeResponseData
+}
+
+type getPurposeResponseData struct {
+	Purpose Purpose
+}
+
+// Purpose returns the contribution purpose with the given ID.
+func (c *Client) Purpose(ctx context.Context, id int) (*Purpose, error) {
+	var gResp getPurposeResponse
+	if err := c.get(ctx, "/purposes/"+strconv.Itoa(id), nil, &gResp); err != nil {
+		return nil, fmt.Errorf("failed to get purpose: %w", err)
+	}
+
+	return &gResp.Data.Purpose, nil
+}
+
+type listPurposesResponse struct {
+	Version string
+	Status  int
+	listPage
+	Data listPurposesResponseData
+}
+
+type listPurposesResponseData struct {
+	Purposes []Purpose
+}
+
+type listPurposesOpts struct {
+	name *string
+}
+
+// WithPurposeName filters purposes to those whose name matches the given
+// string.
+func WithPurposeName(name string) ListPurposeOption {
+	return func(o *listPurposesOpts) {
+		o.name = &name
+	}
+}
+
+type ListPurposeOption func(*listPurposesOpts)
+
+// Purposes returns a list of contribution purposes satisfying the given
+// options. All pages of results are loaded.
+func (c *Client) Purposes(ctx context.Context, opts ...ListPurposeOption) ([]Purpose, error) {
+	o := &listPurposesOpts{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	q := url.Values{}
+	if o.name != nil {
+		q.Add("f_name", *o.name)
+	}
+
+	var purposes []Purpose
+	err := forEachPage(ctx, c, c.url("/purposes", q), func(r *listPurposesResponse) bool {
+		if purposes == nil {
+			purposes = make([]Purpose, 0, r.Meta.RecordCount)
+		}
+		purposes = append(purposes, r.Data.Purposes...)
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list purposes: %w", err)
+	}
+	return purposes, nil
+}
+
+// PurposeByName returns the contribution purpose with the given name, ignoring
+// case, so callers like CreateContribution don't need to hardcode purpose IDs.
+// It returns an error if there isn't exactly one purpose with that name.
+func (c *Client) PurposeByName(ctx context.Context, name string) (*Purpose, error) {
+	purposes, err := c.Purposes(ctx, WithPurposeName(name))
+	if err != nil {
+		return nil, err
+	}
+	var match *Purpose
+	for i, p := range purposes {
+		if !strings.EqualFold(strings.TrimSpace(p.Name), strings.TrimSpace(name)) {
+			continue
+		}
+		if match != nil {
+			return nil, fmt.Errorf("multiple purposes named %q, IDs %d and %d", name, match.ID, p.ID)
+		}
+		match = &purposes[i]
+	}
+	if match == nil {
+		return nil, fmt.Errorf("no purpose named %q", name)
+	}
+	return match, nil
+}
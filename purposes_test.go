@@ -0,0 +1,89 @@
+package aplos
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+const purposesJSON = `{"status": 200, "data": {"purposes": [
+	{"id": 3, "name": "Annual Fund", "is_enabled": true, "fund": {"id": 1, "name": "General"}},
+	{"id": 4, "name": "Annual Fund Match", "is_enabled": true},
+	{"id": 5, "name": "Building", "is_enabled": true},
+	{"id": 6, "name": "building", "is_enabled": false}
+]}}`
+
+func TestPurposes(t *testing.T) {
+	var gotQuery string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/purposes" {
+			t.Errorf("request path = %q, want /purposes", r.URL.Path)
+		}
+		gotQuery = r.URL.RawQuery
+		fmt.Fprint(w, purposesJSON)
+	}))
+
+	got, err := c.Purposes(context.Background(), WithPurposeName("Annual"))
+	if err != nil {
+		t.Fatalf("Purposes: %v", err)
+	}
+	if want := "f_name=Annual"; gotQuery != want {
+		t.Errorf("query = %q, want %q", gotQuery, want)
+	}
+	want := Purpose{ID: 3, Name: "Annual Fund", IsEnabled: true, Fund: &Fund{ID: 1, Name: "General"}}
+	if len(got) != 4 || !reflect.DeepEqual(got[0], want) {
+		t.Errorf("Purposes()[0] = %+v, want %+v", got[0], want)
+	}
+}
+
+func TestPurpose(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/purposes/3" {
+			t.Errorf("request path = %q, want /purposes/3", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"status": 200, "data": {"purpose": {"id": 3, "name": "Annual Fund"}}}`)
+	}))
+
+	got, err := c.Purpose(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("Purpose: %v", err)
+	}
+	if want := (&Purpose{ID: 3, Name: "Annual Fund"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Purpose() = %+v, want %+v", got, want)
+	}
+}
+
+func TestPurposeByName(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, purposesJSON)
+	}))
+
+	tests := []struct {
+		name    string
+		wantID  int
+		wantErr bool
+	}{
+		{name: "annual fund", wantID: 3},
+		{name: "Building", wantErr: true},
+		{name: "Scholarships", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := c.PurposeByName(context.Background(), test.name)
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("PurposeByName() = %+v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("PurposeByName: %v", err)
+			}
+			if got.ID != test.wantID {
+				t.Errorf("PurposeByName() = %d, want %d", got.ID, test.wantID)
+			}
+		})
+	}
+}
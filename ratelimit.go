@@ -0,0 +1,82 @@
+package aplos
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimits configures client-side throttling of API calls, by class of
+// endpoint. Each limit is in requests per second, zero means unlimited.
+type RateLimits struct {
+	// ReadRPS limits GET requests.
+	ReadRPS float64
+	// WriteRPS limits requests that modify data, i.e. POST, PUT, PATCH, and
+	// DELETE requests. These typically have tighter limits on the Aplos side.
+	WriteRPS float64
+	// AuthRPS limits calls to the authentication endpoint.
+	AuthRPS float64
+}
+
+// limiter spaces out calls so that no more than one happens per interval. A nil
+// *limiter doesn't limit anything.
+type limiter struct {
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+func newLimiter(rps float64) *limiter {
+	if rps <= 0 {
+		return nil
+	}
+	return &limiter{interval: time.Duration(float64(time.Second) / rps)}
+}
+
+// Wait blocks until the caller is allowed to proceed, or the context is done.
+func (l *limiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(l.interval)
+	l.mu.Unlock()
+
+	d := at.Sub(now)
+	if d <= 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+type rateLimitTransport struct {
+	next  http.RoundTripper
+	read  *limiter
+	write *limiter
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	l := t.read
+	if isUnsafeMethod(req.Method) {
+		l = t.write
+	}
+	if err := l.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,46 @@
+package aplos
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLimiter(t *testing.T) {
+	l := newLimiter(100)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatalf("Wait: %v", err)
+		}
+	}
+	// The first call proceeds immediately, the remaining four wait 10ms each.
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("five calls at 100 RPS took %v, want at least 40ms", elapsed)
+	}
+}
+
+func TestLimiterUnlimited(t *testing.T) {
+	l := newLimiter(0)
+	if l != nil {
+		t.Fatalf("newLimiter(0) = %+v, want nil", l)
+	}
+	if err := l.Wait(context.Background()); err != nil {
+		t.Errorf("Wait on nil limiter: %v", err)
+	}
+}
+
+func TestLimiterCanceled(t *testing.T) {
+	l := newLimiter(0.1)
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := l.Wait(ctx); err != nil {
+		t.Fatalf("first Wait: %v", err)
+	}
+	cancel()
+	if err := l.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait after cancel = %v, want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,78 @@
+package aplos
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"golang.org/x/oauth2"
+)
+
+// reuseTokenSource is like oauth2.ReuseTokenSource, which caches a token until
+// it expires, but the cached token can also be discarded early, e.g. when the
+// API rejects it because it was revoked.
+type reuseTokenSource struct {
+	new oauth2.TokenSource
+
+	mu sync.Mutex
+	t  *oauth2.Token
+}
+
+func (s *reuseTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.t.Valid() {
+		return s.t, nil
+	}
+	t, err := s.new.Token()
+	if err != nil {
+		return nil, err
+	}
+	s.t = t
+	return t, nil
+}
+
+// current returns the cached token, or nil if there isn't one.
+func (s *reuseTokenSource) current() *oauth2.Token {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.t
+}
+
+// invalidate discards the cached token if it's stale, so the next request
+// authenticates again. If another request already replaced the stale token,
+// the replacement is kept. A nil stale token discards whatever is cached.
+func (s *reuseTokenSource) invalidate(stale *oauth2.Token) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if stale == nil || s.t == stale {
+		s.t = nil
+	}
+}
+
+// doAuthed sends a request like doOnce, but if the API rejects the access
+// token with a 401, e.g. because it expired early or was revoked, it
+// authenticates again and resends the request once.
+func (c *Client) doAuthed(ctx context.Context, method, u string, body []byte, out interface{}) error {
+	tkn := c.tokens.current()
+	err := c.doOnce(ctx, method, u, body, out)
+	if c.tokens == nil || !tokenRejected(err) {
+		return err
+	}
+	c.tokens.invalidate(tkn)
+	return c.doOnce(ctx, method, u, body, out)
+}
+
+// tokenRejected reports whether err is a 401 response from the API. Failures
+// to get a token in the first place come back from the HTTP client wrapped in
+// a *url.Error, and aren't worth retrying, since the credentials are bad.
+func tokenRejected(err error) bool {
+	var apiErr *APIError
+	var urlErr *url.Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized && !errors.As(err, &urlErr)
+}
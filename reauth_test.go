@@ -0,0 +1,35 @@
+package aplos_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Silicon-Ally/aplos/aplostest"
+)
+
+func TestReauthenticateOnRevokedToken(t *testing.T) {
+	srv := aplostest.NewServer()
+	defer srv.Close()
+	srv.Load(aplostest.SmallNonprofit())
+	c, err := srv.Client()
+	if err != nil {
+		t.Fatalf("Client: %v", err)
+	}
+
+	srv.Inject("GET /funds", aplostest.Fault{ExpireToken: true, Times: 1})
+	funds, err := c.Funds(context.Background())
+	if err != nil {
+		t.Fatalf("Funds() with a revoked token = %v, want no error", err)
+	}
+	if len(funds) == 0 {
+		t.Error("Funds() returned no funds")
+	}
+
+	stats := c.Stats()
+	if got, want := stats.Endpoints["GET /auth/{client_id}"].Requests, 2; got != want {
+		t.Errorf("auth requests = %d, want %d", got, want)
+	}
+	if got, want := stats.Endpoints["GET /funds"].Requests, 2; got != want {
+		t.Errorf("funds requests = %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,80 @@
+package report
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/Silicon-Ally/aplos"
+	"github.com/Silicon-Ally/aplos/aplostest"
+)
+
+// benchLedger generates two years of transactions, about 5,000 with 50,000
+// lines, and the chart of accounts. See BENCHMARKS.md for baselines.
+func benchLedger(b *testing.B) ([]aplos.Transaction, []aplos.Account) {
+	b.Helper()
+	d, err := aplostest.Generate(aplostest.GeneratorConfig{Seed: 1, Months: 24, Donors: 2000, ExpensesPerMonth: 200})
+	if err != nil {
+		b.Fatalf("Generate: %v", err)
+	}
+	return d.Transactions, d.Accounts
+}
+
+const benchDefinition = `
+title: Income and expenses by account
+rows:
+  by: account
+  total: true
+columns:
+  period: month
+  start: 2023-01-01
+  end: 2024-12-31
+  total: true
+filters:
+  categories: [income, expense]
+`
+
+func BenchmarkDefinitionRun(b *testing.B) {
+	txns, accts := benchLedger(b)
+	def, err := ParseDefinition(strings.NewReader(benchDefinition))
+	if err != nil {
+		b.Fatalf("ParseDefinition: %v", err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := def.Run(txns, accts, USD); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMonthlyIncomeExpense(b *testing.B) {
+	txns, accts := benchLedger(b)
+	start := aplos.Date{Year: 2023, Month: 1, Day: 1}
+	end := aplos.Date{Year: 2024, Month: 12, Day: 31}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		MonthlyIncomeExpense(txns, accts, start, end)
+	}
+}
+
+func BenchmarkWriteHTML(b *testing.B) {
+	txns, accts := benchLedger(b)
+	def, err := ParseDefinition(strings.NewReader(benchDefinition))
+	if err != nil {
+		b.Fatalf("ParseDefinition: %v", err)
+	}
+	t, err := def.Run(txns, accts, USD)
+	if err != nil {
+		b.Fatalf("Run: %v", err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := WriteHTML(io.Discard, t.Title, []*Table{t}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
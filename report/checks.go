@@ -0,0 +1,128 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/Silicon-Ally/aplos"
+)
+
+// CheckRegister lists the checks written from a register, ordered by check
+// number.
+//
+// The Aplos transaction types don't currently expose cleared status, so it
+// isn't included.
+type CheckRegister struct {
+	Checks []Check
+	// Gaps lists runs of check numbers that are missing between the lowest and
+	// highest check numbers in the register.
+	Gaps []NumberRange
+	// Duplicates lists check numbers that appear on more than one transaction.
+	Duplicates []int
+}
+
+// Check is a single check in a CheckRegister.
+type Check struct {
+	Number int
+	Date   aplos.Date
+	Amount aplos.Amount
+	// Payee is the name of the transaction's contact, or "" if it has none.
+	Payee         string
+	Memo          string
+	TransactionID int
+}
+
+// NumberRange is an inclusive range of check numbers.
+type NumberRange struct {
+	First, Last int
+}
+
+func (r NumberRange) String() string {
+	if r.First == r.Last {
+		return fmt.Sprint(r.First)
+	}
+	return fmt.Sprintf("%d-%d", r.First, r.Last)
+}
+
+// LoadCheckRegister loads transactions for the given register account in the
+// given period, and builds a CheckRegister from them.
+func LoadCheckRegister(ctx context.Context, c *aplos.Client, accountNumber int, start, end aplos.Date) (*CheckRegister, error) {
+	txns, err := c.Transactions(ctx,
+		aplos.WithAccountNumber(accountNumber),
+		aplos.WithRangeStart(start.Year, start.Month, start.Day),
+		aplos.WithRangeEnd(end.Year, end.Month, end.Day),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load transactions: %w", err)
+	}
+	return NewCheckRegister(txns), nil
+}
+
+// NewCheckRegister builds a CheckRegister from the given transactions. Check
+// numbers are read from the transactions' reference number (IDNumber), and
+// transactions without one are assumed not to be checks and are skipped.
+func NewCheckRegister(txns []aplos.Transaction) *CheckRegister {
+	cr := &CheckRegister{}
+	for _, t := range txns {
+		if t.IDNumber <= 0 {
+			continue
+		}
+		var payee string
+		if t.Contact != nil {
+			payee = t.Contact.Name()
+		}
+		cr.Checks = append(cr.Checks, Check{
+			Number:        int(t.IDNumber),
+			Date:          t.Date,
+			Amount:        t.Amount,
+			Payee:         payee,
+			Memo:          t.Memo,
+			TransactionID: t.ID,
+		})
+	}
+
+	sort.SliceStable(cr.Checks, func(i, j int) bool {
+		return cr.Checks[i].Number < cr.Checks[j].Number
+	})
+
+	for i := 1; i < len(cr.Checks); i++ {
+		prev, cur := cr.Checks[i-1].Number, cr.Checks[i].Number
+		switch {
+		case cur == prev:
+			if n := len(cr.Duplicates); n == 0 || cr.Duplicates[n-1] != cur {
+				cr.Duplicates = append(cr.Duplicates, cur)
+			}
+		case cur > prev+1:
+			cr.Gaps = append(cr.Gaps, NumberRange{First: prev + 1, Last: cur - 1})
+		}
+	}
+
+	return cr
+}
+
+// Table lays out the check register with one row per check, followed by rows
+// noting any gaps and duplicates.
+func (cr *CheckRegister) Table(f CurrencyFormat) *Table {
+	t := &Table{
+		Title: "Check register",
+		Columns: []Column{
+			{Header: "Number", Align: AlignRight},
+			{Header: "Date"},
+			{Header: "Payee"},
+			{Header: "Amount", Align: AlignRight},
+			{Header: "Memo"},
+		},
+	}
+	for _, c := range cr.Checks {
+		t.Rows = append(t.Rows, []string{strconv.Itoa(c.Number), c.Date.String(), c.Payee, f.FormatAmount(c.Amount), c.Memo})
+	}
+	for _, g := range cr.Gaps {
+		t.Rows = append(t.Rows, []string{g.String(), "", "", "", "Missing"})
+	}
+	for _, n := range cr.Duplicates {
+		t.Rows = append(t.Rows, []string{strconv.Itoa(n), "", "", "", "Duplicate"})
+	}
+	return t
+}
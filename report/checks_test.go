@@ -0,0 +1,46 @@
+package report
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Silicon-Ally/aplos"
+)
+
+func TestNewCheckRegister(t *testing.T) {
+	date := aplos.Date{Year: 2023, Month: time.March, Day: 1}
+	txns := []aplos.Transaction{
+		{ID: 1, IDNumber: 1003, Date: date, Amount: 30_00, Memo: "C"},
+		{ID: 2, IDNumber: 1001, Date: date, Amount: 10_00, Memo: "A", Contact: &aplos.Contact{Type: aplos.ContactTypeCompany, CompanyName: "Acme Corp"}},
+		{ID: 3, Date: date, Amount: 500_00, Memo: "Deposit"},
+		{ID: 4, IDNumber: 1007, Date: date, Amount: 70_00, Memo: "G"},
+		{ID: 5, IDNumber: 1003, Date: date, Amount: 31_00, Memo: "C again"},
+		{ID: 6, IDNumber: 1002, Date: date, Amount: 20_00, Memo: "B", Contact: &aplos.Contact{FirstName: "Grace", LastName: "Hopper"}},
+	}
+
+	got := NewCheckRegister(txns)
+	want := &CheckRegister{
+		Checks: []Check{
+			{Number: 1001, Date: date, Amount: 10_00, Payee: "Acme Corp", Memo: "A", TransactionID: 2},
+			{Number: 1002, Date: date, Amount: 20_00, Payee: "Grace Hopper", Memo: "B", TransactionID: 6},
+			{Number: 1003, Date: date, Amount: 30_00, Memo: "C", TransactionID: 1},
+			{Number: 1003, Date: date, Amount: 31_00, Memo: "C again", TransactionID: 5},
+			{Number: 1007, Date: date, Amount: 70_00, Memo: "G", TransactionID: 4},
+		},
+		Gaps:       []NumberRange{{First: 1004, Last: 1006}},
+		Duplicates: []int{1003},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewCheckRegister() = %+v, want %+v", got, want)
+	}
+}
+
+func TestNumberRangeString(t *testing.T) {
+	if got, want := (NumberRange{First: 5, Last: 5}).String(), "5"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got, want := (NumberRange{First: 5, Last: 9}).String(), "5-9"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
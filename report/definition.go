@@ -0,0 +1,307 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Silicon-Ally/aplos"
+	"gopkg.in/yaml.v3"
+)
+
+// Definition is a declarative report, typically written in YAML by finance
+// staff, that sums transaction line amounts into a grid. For example:
+//
+//	title: Income by fund
+//	rows:
+//	  by: fund
+//	  total: true
+//	columns:
+//	  period: quarter
+//	  start: 2023-01-01
+//	  end: 2023-12-31
+//	  total: true
+//	filters:
+//	  categories: [income]
+//	negate: true
+type Definition struct {
+	Title   string     `yaml:"title"`
+	Rows    RowSpec    `yaml:"rows"`
+	Columns ColumnSpec `yaml:"columns"`
+	Filters FilterSpec `yaml:"filters"`
+	// Negate flips the sign of amounts, which are normally debit-positive, so
+	// that e.g. income shows up as positive numbers.
+	Negate bool `yaml:"negate"`
+}
+
+// RowSpec configures the rows of a report.
+type RowSpec struct {
+	// By is what each row represents: "fund", "account_group", or "account".
+	By string `yaml:"by"`
+	// Total adds a row summing each column.
+	Total bool `yaml:"total"`
+}
+
+// ColumnSpec configures the columns of a report.
+type ColumnSpec struct {
+	// Period is the length of time each column covers: "month", "quarter", or
+	// "year".
+	Period string `yaml:"period"`
+	// Start and End are the first and last days of the report, formatted like
+	// 2023-01-31.
+	Start string `yaml:"start"`
+	End   string `yaml:"end"`
+	// Total adds a column summing each row.
+	Total bool `yaml:"total"`
+}
+
+// FilterSpec limits which transaction lines are included in a report. Empty
+// filters include everything.
+type FilterSpec struct {
+	// Categories lists account categories, like "income" or "expense".
+	Categories []string `yaml:"categories"`
+	// AccountGroups lists account group names.
+	AccountGroups []string `yaml:"account_groups"`
+	// Accounts lists account numbers.
+	Accounts []int `yaml:"accounts"`
+	// Funds lists fund IDs.
+	Funds []int `yaml:"funds"`
+}
+
+// ParseDefinition reads a YAML report definition and validates it.
+func ParseDefinition(r io.Reader) (*Definition, error) {
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	var d Definition
+	if err := dec.Decode(&d); err != nil {
+		return nil, fmt.Errorf("failed to parse report definition: %w", err)
+	}
+	if _, _, err := d.dateRange(); err != nil {
+		return nil, err
+	}
+	switch d.Rows.By {
+	case "fund", "account_group", "account":
+	default:
+		return nil, fmt.Errorf("rows.by must be one of fund, account_group, or account, was %q", d.Rows.By)
+	}
+	switch d.Columns.Period {
+	case "month", "quarter", "year":
+	default:
+		return nil, fmt.Errorf("columns.period must be one of month, quarter, or year, was %q", d.Columns.Period)
+	}
+	return &d, nil
+}
+
+func (d *Definition) dateRange() (aplos.Date, aplos.Date, error) {
+	start, err := parseDate(d.Columns.Start)
+	if err != nil {
+		return aplos.Date{}, aplos.Date{}, fmt.Errorf("invalid columns.start: %w", err)
+	}
+	end, err := parseDate(d.Columns.End)
+	if err != nil {
+		return aplos.Date{}, aplos.Date{}, fmt.Errorf("invalid columns.end: %w", err)
+	}
+	if end.Before(start) {
+		return aplos.Date{}, aplos.Date{}, fmt.Errorf("columns.end %s is before columns.start %s", end, start)
+	}
+	return start, end, nil
+}
+
+func parseDate(s string) (aplos.Date, error) {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return aplos.Date{}, err
+	}
+	return aplos.Date{Year: t.Year(), Month: t.Month(), Day: t.Day()}, nil
+}
+
+type defRow struct {
+	label string
+	// acct is the account number for rows by account, used for ordering.
+	acct int
+	sums []aplos.Amount
+}
+
+// Run executes the definition against the given data, returning a Table with
+// amounts formatted with f. Transactions must include their lines (e.g. via
+// Client.Transaction), and accounts are used to look up categories, groups,
+// and names.
+func (d *Definition) Run(txns []aplos.Transaction, accts []aplos.Account, f CurrencyFormat) (*Table, error) {
+	start, end, err := d.dateRange()
+	if err != nil {
+		return nil, err
+	}
+	labels, idx := periods(start, end, d.Columns.Period)
+
+	chart := make(map[int]aplos.Account)
+	for _, a := range accts {
+		chart[a.AccountNumber] = a
+	}
+	include := d.Filters.matcher(chart)
+	sign := aplos.Amount(1)
+	if d.Negate {
+		sign = -1
+	}
+
+	rows := make(map[string]*defRow)
+	for _, t := range txns {
+		if t.Date.Before(start) || t.Date.After(end) {
+			continue
+		}
+		col := idx[periodLabel(t.Date, d.Columns.Period)]
+		for _, l := range t.Lines {
+			if !include(l) {
+				continue
+			}
+			key, acct := d.rowKey(l, chart)
+			r, ok := rows[key]
+			if !ok {
+				r = &defRow{label: key, acct: acct, sums: make([]aplos.Amount, len(labels))}
+				rows[key] = r
+			}
+			r.sums[col] += sign * l.Amount
+		}
+	}
+
+	sorted := make([]*defRow, 0, len(rows))
+	for _, r := range rows {
+		sorted = append(sorted, r)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].acct != sorted[j].acct {
+			return sorted[i].acct < sorted[j].acct
+		}
+		return sorted[i].label < sorted[j].label
+	})
+	if d.Rows.Total {
+		total := &defRow{label: "Total", sums: make([]aplos.Amount, len(labels))}
+		for _, r := range sorted {
+			for i, v := range r.sums {
+				total.sums[i] += v
+			}
+		}
+		sorted = append(sorted, total)
+	}
+
+	t := &Table{Title: d.Title, Columns: []Column{{Header: rowHeader(d.Rows.By)}}}
+	for _, l := range labels {
+		t.Columns = append(t.Columns, Column{Header: l, Align: AlignRight})
+	}
+	if d.Columns.Total {
+		t.Columns = append(t.Columns, Column{Header: "Total", Align: AlignRight})
+	}
+	for _, r := range sorted {
+		row := []string{r.label}
+		var total aplos.Amount
+		for _, v := range r.sums {
+			row = append(row, f.FormatAmount(v))
+			total += v
+		}
+		if d.Columns.Total {
+			row = append(row, f.FormatAmount(total))
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	return t, nil
+}
+
+func (d *Definition) rowKey(l aplos.TransactionLine, chart map[int]aplos.Account) (string, int) {
+	switch d.Rows.By {
+	case "fund":
+		if l.Fund.Name != "" {
+			return l.Fund.Name, 0
+		}
+		return "Fund " + strconv.Itoa(l.Fund.ID), 0
+	case "account_group":
+		if g := chart[l.Account.AccountNumber].AccountGroup; g != nil && g.Name != "" {
+			return g.Name, 0
+		}
+		return "Ungrouped", 0
+	default:
+		n := l.Account.AccountNumber
+		name := chart[n].Name
+		if name == "" {
+			name = l.Account.Name
+		}
+		return strings.TrimSpace(strconv.Itoa(n) + " " + name), n
+	}
+}
+
+func rowHeader(by string) string {
+	switch by {
+	case "fund":
+		return "Fund"
+	case "account_group":
+		return "Account group"
+	default:
+		return "Account"
+	}
+}
+
+func (fs *FilterSpec) matcher(chart map[int]aplos.Account) func(aplos.TransactionLine) bool {
+	return func(l aplos.TransactionLine) bool {
+		acct := chart[l.Account.AccountNumber]
+		if len(fs.Categories) > 0 && !containsFold(fs.Categories, acct.Category) {
+			return false
+		}
+		if len(fs.AccountGroups) > 0 && (acct.AccountGroup == nil || !containsFold(fs.AccountGroups, acct.AccountGroup.Name)) {
+			return false
+		}
+		if len(fs.Accounts) > 0 && !containsInt(fs.Accounts, l.Account.AccountNumber) {
+			return false
+		}
+		if len(fs.Funds) > 0 && !containsInt(fs.Funds, l.Fund.ID) {
+			return false
+		}
+		return true
+	}
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInt(list []int, n int) bool {
+	for _, v := range list {
+		if v == n {
+			return true
+		}
+	}
+	return false
+}
+
+// periods returns labels for each period from start to end, inclusive, and a
+// map from label to index.
+func periods(start, end aplos.Date, period string) ([]string, map[string]int) {
+	var labels []string
+	idx := make(map[string]int)
+	cur := time.Date(start.Year, start.Month, 1, 0, 0, 0, 0, time.UTC)
+	for !cur.After(end.Time()) {
+		l := periodLabel(aplos.Date{Year: cur.Year(), Month: cur.Month(), Day: 1}, period)
+		if _, ok := idx[l]; !ok {
+			idx[l] = len(labels)
+			labels = append(labels, l)
+		}
+		cur = cur.AddDate(0, 1, 0)
+	}
+	return labels, idx
+}
+
+func periodLabel(d aplos.Date, period string) string {
+	switch period {
+	case "quarter":
+		return fmt.Sprintf("%04d-Q%d", d.Year, (int(d.Month)-1)/3+1)
+	case "year":
+		return fmt.Sprintf("%04d", d.Year)
+	default:
+		return monthLabel(d)
+	}
+}
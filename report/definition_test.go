@@ -0,0 +1,94 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Silicon-Ally/aplos"
+)
+
+const incomeByFund = `
+title: Income by fund
+rows:
+  by: fund
+  total: true
+columns:
+  period: quarter
+  start: 2023-01-01
+  end: 2023-06-30
+  total: true
+filters:
+  categories: [Income]
+negate: true
+`
+
+func TestDefinitionRun(t *testing.T) {
+	def, err := ParseDefinition(strings.NewReader(incomeByFund))
+	if err != nil {
+		t.Fatalf("ParseDefinition: %v", err)
+	}
+
+	accts := []aplos.Account{
+		{AccountNumber: 1000, Name: "Checking", Category: "asset"},
+		{AccountNumber: 4000, Name: "Donations", Category: "income"},
+		{AccountNumber: 4100, Name: "Grants", Category: "income"},
+	}
+	general := aplos.Fund{ID: 1, Name: "General"}
+	building := aplos.Fund{ID: 2, Name: "Building"}
+	line := func(acct int, fund aplos.Fund, amt aplos.Amount) aplos.TransactionLine {
+		return aplos.TransactionLine{Amount: amt, Account: aplos.Account{AccountNumber: acct}, Fund: fund}
+	}
+	txns := []aplos.Transaction{
+		{Date: aplos.Date{Year: 2023, Month: time.February, Day: 1}, Lines: []aplos.TransactionLine{
+			line(1000, general, 100_00), line(4000, general, -100_00),
+		}},
+		{Date: aplos.Date{Year: 2023, Month: time.May, Day: 15}, Lines: []aplos.TransactionLine{
+			line(1000, general, 300_00), line(4100, general, -250_00), line(4000, building, -50_00),
+		}},
+		// Outside the date range.
+		{Date: aplos.Date{Year: 2023, Month: time.July, Day: 1}, Lines: []aplos.TransactionLine{
+			line(1000, general, 999_00), line(4000, general, -999_00),
+		}},
+	}
+
+	tbl, err := def.Run(txns, accts, USD)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	var sb strings.Builder
+	if err := WriteText(&sb, tbl); err != nil {
+		t.Fatalf("WriteText: %v", err)
+	}
+	want := `Income by fund
+
+Fund      2023-Q1  2023-Q2    Total
+--------  -------  -------  -------
+Building    $0.00   $50.00   $50.00
+General   $100.00  $250.00  $350.00
+Total     $100.00  $300.00  $400.00
+`
+	if got := sb.String(); got != want {
+		t.Errorf("Run() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestParseDefinitionErrors(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   string
+	}{
+		{desc: "unknown field", in: "title: x\nrowz: {by: fund}\ncolumns: {period: month, start: 2023-01-01, end: 2023-01-31}"},
+		{desc: "bad row kind", in: "rows: {by: donor}\ncolumns: {period: month, start: 2023-01-01, end: 2023-01-31}"},
+		{desc: "bad period", in: "rows: {by: fund}\ncolumns: {period: week, start: 2023-01-01, end: 2023-01-31}"},
+		{desc: "bad date", in: "rows: {by: fund}\ncolumns: {period: month, start: January, end: 2023-01-31}"},
+		{desc: "end before start", in: "rows: {by: fund}\ncolumns: {period: month, start: 2023-02-01, end: 2023-01-31}"},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if _, err := ParseDefinition(strings.NewReader(test.in)); err == nil {
+				t.Error("ParseDefinition() returned no error, want one")
+			}
+		})
+	}
+}
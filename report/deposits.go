@@ -0,0 +1,168 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/Silicon-Ally/aplos"
+)
+
+// defaultDepositWindow is how many days after a batch's date a bank deposit
+// can be made and still match it.
+const defaultDepositWindow = 5
+
+// DepositReconciliation compares recorded contributions against deposits to
+// the bank, to find gifts that were recorded but never deposited, or deposits
+// with no recorded gifts behind them.
+type DepositReconciliation struct {
+	// Undeposited lists contributions that aren't in a deposited batch, ordered
+	// by date.
+	Undeposited []aplos.Contribution
+	// UnmatchedBatches lists batches marked as deposited that don't match any
+	// bank deposit.
+	UnmatchedBatches []DepositBatch
+	// UnmatchedDeposits lists bank deposits that don't match any deposited
+	// batch.
+	UnmatchedDeposits []aplos.Transaction
+}
+
+// DepositBatch is a deposited batch of contributions.
+type DepositBatch struct {
+	Batch         aplos.ContributionBatch
+	Total         aplos.Amount
+	Contributions []aplos.Contribution
+}
+
+// LoadDepositReconciliation loads contributions and the deposits to the given
+// bank account in the given period, and reconciles them with
+// NewDepositReconciliation.
+//
+// Deposits are found by loading each transaction in the bank account's
+// register with its lines, and keeping those that debit the account, so this
+// makes one request per transaction.
+func LoadDepositReconciliation(ctx context.Context, c *aplos.Client, bankAccount int, start, end aplos.Date) (*DepositReconciliation, error) {
+	contribs, err := c.Contributions(ctx,
+		aplos.WithContributionRangeStart(start.Year, start.Month, start.Day),
+		aplos.WithContributionRangeEnd(end.Year, end.Month, end.Day),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load contributions: %w", err)
+	}
+	// Deposits can be made a few days after the contributions they include.
+	depEnd := end.AddDays(defaultDepositWindow)
+	txns, err := c.Transactions(ctx,
+		aplos.WithAccountNumber(bankAccount),
+		aplos.WithRangeStart(start.Year, start.Month, start.Day),
+		aplos.WithRangeEnd(depEnd.Year, depEnd.Month, depEnd.Day),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bank transactions: %w", err)
+	}
+
+	var deposits []aplos.Transaction
+	for _, t := range txns {
+		full, err := c.Transaction(ctx, t.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load transaction %d: %w", t.ID, err)
+		}
+		var amt aplos.Amount
+		for _, l := range full.Lines {
+			if l.Account.AccountNumber == bankAccount {
+				amt += l.Amount
+			}
+		}
+		if amt > 0 {
+			full.Amount = amt
+			deposits = append(deposits, *full)
+		}
+	}
+	return NewDepositReconciliation(contribs, deposits, defaultDepositWindow), nil
+}
+
+// NewDepositReconciliation reconciles contributions against bank deposits,
+// whose Amount is the amount deposited. A deposited batch matches a deposit of
+// the same total made on the batch's date or up to windowDays after it. Each
+// deposit matches at most one batch, with earlier batches matched first.
+func NewDepositReconciliation(contribs []aplos.Contribution, deposits []aplos.Transaction, windowDays int) *DepositReconciliation {
+	r := &DepositReconciliation{}
+
+	batches := make(map[int]*DepositBatch)
+	var batchIDs []int
+	for _, c := range contribs {
+		if c.Batch == nil || !c.Batch.Deposited {
+			r.Undeposited = append(r.Undeposited, c)
+			continue
+		}
+		b, ok := batches[c.Batch.ID]
+		if !ok {
+			b = &DepositBatch{Batch: *c.Batch}
+			batches[c.Batch.ID] = b
+			batchIDs = append(batchIDs, c.Batch.ID)
+		}
+		b.Contributions = append(b.Contributions, c)
+		b.Total += c.Amount
+	}
+	sort.SliceStable(r.Undeposited, func(i, j int) bool {
+		return r.Undeposited[i].Date.Before(r.Undeposited[j].Date)
+	})
+	sort.SliceStable(batchIDs, func(i, j int) bool {
+		return batches[batchIDs[i]].Batch.Date.Before(batches[batchIDs[j]].Batch.Date)
+	})
+
+	matched := make([]bool, len(deposits))
+	for _, id := range batchIDs {
+		b := batches[id]
+		from := b.Batch.Date
+		to := from.AddDays(windowDays)
+		best := -1
+		for i, dep := range deposits {
+			if matched[i] || dep.Amount != b.Total {
+				continue
+			}
+			if dep.Date.Before(from) || dep.Date.After(to) {
+				continue
+			}
+			if best == -1 || dep.Date.Before(deposits[best].Date) {
+				best = i
+			}
+		}
+		if best == -1 {
+			r.UnmatchedBatches = append(r.UnmatchedBatches, *b)
+			continue
+		}
+		matched[best] = true
+	}
+
+	for i, dep := range deposits {
+		if !matched[i] {
+			r.UnmatchedDeposits = append(r.UnmatchedDeposits, dep)
+		}
+	}
+	return r
+}
+
+// Table lays out the reconciliation with one row per undeposited contribution,
+// unmatched batch, and unmatched deposit.
+func (r *DepositReconciliation) Table(f CurrencyFormat) *Table {
+	t := &Table{
+		Title: "Deposit reconciliation",
+		Columns: []Column{
+			{Header: "Issue"},
+			{Header: "Date"},
+			{Header: "ID", Align: AlignRight},
+			{Header: "Amount", Align: AlignRight},
+		},
+	}
+	for _, c := range r.Undeposited {
+		t.Rows = append(t.Rows, []string{"Undeposited contribution", c.Date.String(), strconv.Itoa(c.ID), f.FormatAmount(c.Amount)})
+	}
+	for _, b := range r.UnmatchedBatches {
+		t.Rows = append(t.Rows, []string{"Batch without bank deposit", b.Batch.Date.String(), strconv.Itoa(b.Batch.ID), f.FormatAmount(b.Total)})
+	}
+	for _, d := range r.UnmatchedDeposits {
+		t.Rows = append(t.Rows, []string{"Bank deposit without batch", d.Date.String(), strconv.Itoa(d.ID), f.FormatAmount(d.Amount)})
+	}
+	return t
+}
@@ -0,0 +1,55 @@
+package report
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Silicon-Ally/aplos"
+)
+
+func TestNewDepositReconciliation(t *testing.T) {
+	d := func(day int) aplos.Date { return aplos.Date{Year: 2023, Month: time.May, Day: day} }
+	batch := func(id, day int) *aplos.ContributionBatch {
+		return &aplos.ContributionBatch{ID: id, Date: d(day), Deposited: true}
+	}
+
+	contribs := []aplos.Contribution{
+		// Batch 1 is deposited two days later.
+		{ID: 1, Date: d(1), Amount: 100_10, Batch: batch(1, 1)},
+		{ID: 2, Date: d(1), Amount: 49_90, Batch: batch(1, 1)},
+		// Batch 2 has no matching deposit, the only one with the same amount is
+		// too late.
+		{ID: 3, Date: d(8), Amount: 75_00, Batch: batch(2, 8)},
+		// Not deposited.
+		{ID: 4, Date: d(10), Amount: 20_00},
+		{ID: 5, Date: d(9), Amount: 30_00, Batch: &aplos.ContributionBatch{ID: 3, Date: d(9)}},
+	}
+	deposits := []aplos.Transaction{
+		{ID: 101, Date: d(3), Amount: 150_00},
+		{ID: 102, Date: d(20), Amount: 75_00},
+		{ID: 103, Date: d(11), Amount: 500_00},
+	}
+
+	got := NewDepositReconciliation(contribs, deposits, 5)
+
+	var undeposited []int
+	for _, c := range got.Undeposited {
+		undeposited = append(undeposited, c.ID)
+	}
+	if want := []int{5, 4}; !reflect.DeepEqual(undeposited, want) {
+		t.Errorf("Undeposited = %v, want %v", undeposited, want)
+	}
+
+	if len(got.UnmatchedBatches) != 1 || got.UnmatchedBatches[0].Batch.ID != 2 || got.UnmatchedBatches[0].Total != 75_00 {
+		t.Errorf("UnmatchedBatches = %+v, want batch 2 totaling 75", got.UnmatchedBatches)
+	}
+
+	var unmatched []int
+	for _, dep := range got.UnmatchedDeposits {
+		unmatched = append(unmatched, dep.ID)
+	}
+	if want := []int{102, 103}; !reflect.DeepEqual(unmatched, want) {
+		t.Errorf("UnmatchedDeposits = %v, want %v", unmatched, want)
+	}
+}
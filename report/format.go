@@ -0,0 +1,136 @@
+package report
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/Silicon-Ally/aplos"
+)
+
+// CurrencyFormat describes how to render monetary amounts as text.
+type CurrencyFormat struct {
+	// Symbol is the currency symbol, like "$" or "€".
+	Symbol string
+	// SymbolAfter places the symbol after the number, separated by a space, as
+	// is conventional in e.g. German and French.
+	SymbolAfter bool
+	// ThousandsSep separates groups of three digits, like "," in "1,000".
+	ThousandsSep string
+	// DecimalSep separates whole and fractional parts, like "." in "1.50".
+	DecimalSep string
+	// Decimals is the number of fractional digits to show. Negative values are
+	// treated as zero.
+	Decimals int
+	// Accounting renders negative amounts in parentheses, like "($1,000.00)",
+	// instead of with a minus sign.
+	Accounting bool
+}
+
+var (
+	// USD formats amounts like "-$1,234.56".
+	USD = CurrencyFormat{Symbol: "$", ThousandsSep: ",", DecimalSep: ".", Decimals: 2}
+	// USDAccounting formats amounts like "($1,234.56)".
+	USDAccounting = CurrencyFormat{Symbol: "$", ThousandsSep: ",", DecimalSep: ".", Decimals: 2, Accounting: true}
+)
+
+var localeFormats = map[string]CurrencyFormat{
+	"en-us": USD,
+	"en-ca": USD,
+	"en-gb": {Symbol: "£", ThousandsSep: ",", DecimalSep: ".", Decimals: 2},
+	"en-au": {Symbol: "$", ThousandsSep: ",", DecimalSep: ".", Decimals: 2},
+	"es-mx": {Symbol: "$", ThousandsSep: ",", DecimalSep: ".", Decimals: 2},
+	"fr-ca": {Symbol: "$", SymbolAfter: true, ThousandsSep: " ", DecimalSep: ",", Decimals: 2},
+	"fr-fr": {Symbol: "€", SymbolAfter: true, ThousandsSep: " ", DecimalSep: ",", Decimals: 2},
+	"de-de": {Symbol: "€", SymbolAfter: true, ThousandsSep: ".", DecimalSep: ",", Decimals: 2},
+	"es-es": {Symbol: "€", SymbolAfter: true, ThousandsSep: ".", DecimalSep: ",", Decimals: 2},
+	"nl-nl": {Symbol: "€", ThousandsSep: ".", DecimalSep: ",", Decimals: 2},
+}
+
+// FormatForLocale returns the conventional currency format for a locale given
+// as a BCP 47 tag like "en-US" or "de_DE". It returns false for locales it
+// doesn't know about.
+func FormatForLocale(locale string) (CurrencyFormat, bool) {
+	f, ok := localeFormats[strings.ToLower(strings.ReplaceAll(locale, "_", "-"))]
+	return f, ok
+}
+
+// FormatAmount renders amt according to the format. It works from amt's cents
+// with integer math, so it's exact for any amount.
+func (f CurrencyFormat) FormatAmount(amt aplos.Amount) string {
+	cents := amt.Cents()
+	neg := cents < 0
+	if neg {
+		cents = -cents
+	}
+	decimals := f.decimals()
+	var units int64
+	if decimals >= 2 {
+		units = cents * pow10(decimals-2)
+	} else {
+		// Round half away from zero, like Format.
+		scale := pow10(2 - decimals)
+		units = (cents + scale/2) / scale
+	}
+	return f.format(units, neg && units != 0)
+}
+
+// Format renders amt according to the format.
+func (f CurrencyFormat) Format(amt float64) string {
+	units := int64(math.Round(math.Abs(amt) * math.Pow10(f.decimals())))
+	return f.format(units, amt < 0 && units != 0)
+}
+
+// decimals returns the number of fractional digits to show, treating negative
+// values as zero.
+func (f CurrencyFormat) decimals() int {
+	return max(f.Decimals, 0)
+}
+
+func pow10(n int) int64 {
+	p := int64(1)
+	for range n {
+		p *= 10
+	}
+	return p
+}
+
+// format renders an amount given as a non-negative number of units of the
+// smallest fractional digit shown.
+func (f CurrencyFormat) format(units int64, neg bool) string {
+	decimals := f.decimals()
+	scale := pow10(decimals)
+
+	whole := strconv.FormatInt(units/scale, 10)
+	var b strings.Builder
+	for i, c := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteString(f.ThousandsSep)
+		}
+		b.WriteRune(c)
+	}
+	if decimals > 0 {
+		frac := strconv.FormatInt(units%scale, 10)
+		b.WriteString(f.DecimalSep)
+		b.WriteString(strings.Repeat("0", decimals-len(frac)))
+		b.WriteString(frac)
+	}
+
+	num := b.String()
+	if f.Symbol != "" {
+		if f.SymbolAfter {
+			num += " " + f.Symbol
+		} else {
+			num = f.Symbol + num
+		}
+	}
+
+	switch {
+	case !neg:
+		return num
+	case f.Accounting:
+		return "(" + num + ")"
+	default:
+		return "-" + num
+	}
+}
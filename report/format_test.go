@@ -0,0 +1,76 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/Silicon-Ally/aplos"
+)
+
+func TestCurrencyFormat(t *testing.T) {
+	de, _ := FormatForLocale("de_DE")
+	tests := []struct {
+		desc string
+		f    CurrencyFormat
+		in   float64
+		want string
+	}{
+		{desc: "zero", f: USD, in: 0, want: "$0.00"},
+		{desc: "small", f: USD, in: 5.5, want: "$5.50"},
+		{desc: "thousands", f: USD, in: 1234567.891, want: "$1,234,567.89"},
+		{desc: "exact thousand", f: USD, in: 1000, want: "$1,000.00"},
+		{desc: "negative", f: USD, in: -1234.5, want: "-$1,234.50"},
+		{desc: "accounting negative", f: USDAccounting, in: -1234.5, want: "($1,234.50)"},
+		{desc: "accounting positive", f: USDAccounting, in: 1234.5, want: "$1,234.50"},
+		{desc: "rounds to negative zero", f: USD, in: -0.001, want: "$0.00"},
+		{desc: "rounding", f: USD, in: 0.125, want: "$0.13"},
+		{desc: "german", f: de, in: -1234.5, want: "-1.234,50 €"},
+		{desc: "no decimals", f: CurrencyFormat{Symbol: "¥", ThousandsSep: ","}, in: 1234.5, want: "¥1,235"},
+		{desc: "negative decimals", f: CurrencyFormat{Symbol: "$", Decimals: -1}, in: 12.5, want: "$13"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if got := test.f.Format(test.in); got != test.want {
+				t.Errorf("Format(%v) = %q, want %q", test.in, got, test.want)
+			}
+		})
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		desc string
+		f    CurrencyFormat
+		in   aplos.Amount
+		want string
+	}{
+		{desc: "zero", f: USD, in: 0, want: "$0.00"},
+		{desc: "thousands", f: USD, in: 1234567_89, want: "$1,234,567.89"},
+		{desc: "negative", f: USD, in: -1234_50, want: "-$1,234.50"},
+		{desc: "accounting negative", f: USDAccounting, in: -1234_50, want: "($1,234.50)"},
+		// Too large to be exact as a float64.
+		{desc: "large", f: USD, in: 9007199254740993, want: "$90,071,992,547,409.93"},
+		{desc: "more decimals", f: CurrencyFormat{DecimalSep: ".", Decimals: 4}, in: 1_05, want: "1.0500"},
+		{desc: "no decimals", f: CurrencyFormat{Symbol: "¥", ThousandsSep: ","}, in: 1234_50, want: "¥1,235"},
+		{desc: "no decimals negative", f: CurrencyFormat{Symbol: "¥"}, in: -1234_50, want: "-¥1235"},
+		{desc: "rounds to negative zero", f: CurrencyFormat{Symbol: "¥"}, in: -49, want: "¥0"},
+		{desc: "negative decimals", f: CurrencyFormat{Symbol: "$", Decimals: -3}, in: 12_50, want: "$13"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if got := test.f.FormatAmount(test.in); got != test.want {
+				t.Errorf("FormatAmount(%d) = %q, want %q", test.in, got, test.want)
+			}
+		})
+	}
+}
+
+func TestFormatForLocale(t *testing.T) {
+	if _, ok := FormatForLocale("en-US"); !ok {
+		t.Error("FormatForLocale(en-US) returned false, want true")
+	}
+	if _, ok := FormatForLocale("xx-YY"); ok {
+		t.Error("FormatForLocale(xx-YY) returned true, want false")
+	}
+}
@@ -0,0 +1,157 @@
+package report
+
+import (
+	"bufio"
+	"fmt"
+	"html/template"
+	"io"
+)
+
+// DefaultStylesheet is the CSS included in HTML reports unless overridden with
+// WithStylesheet.
+const DefaultStylesheet = `body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; color: #222; margin: 2em; }
+h1 { font-size: 1.5em; }
+h2 { font-size: 1.2em; margin-top: 2em; }
+table { border-collapse: collapse; margin: 1em 0; }
+th, td { padding: 0.3em 0.8em; border-bottom: 1px solid #ddd; }
+th { background: #f4f4f4; text-align: left; }
+.right { text-align: right; font-variant-numeric: tabular-nums; }
+`
+
+const defaultHTML = `{{define "page" -}}
+<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+{{.Stylesheet}}</style>
+</head>
+<body>
+{{- if .Title}}
+<h1>{{.Title}}</h1>
+{{- end}}
+{{- range .Tables}}
+{{template "table" .}}
+{{- end}}
+</body>
+</html>
+{{end}}
+{{- define "table" -}}
+{{if .Title}}<h2>{{.Title}}</h2>
+{{end -}}
+<table>
+<thead>
+<tr>{{range .Header}}<th class="{{.Align}}">{{.Text}}</th>{{end}}</tr>
+</thead>
+<tbody>
+{{- range .Rows}}
+<tr>{{range .}}<td class="{{.Align}}">{{.Text}}</td>{{end}}</tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}`
+
+var defaultHTMLTemplate = template.Must(template.New("report").Parse(defaultHTML))
+
+// DefaultHTMLTemplate returns a copy of the template used by WriteHTML. It
+// defines a "page" template, executed with an *HTMLPage, which renders each
+// table with a "table" template. Either can be redefined by parsing into the
+// copy, e.g. to customize table markup while keeping the rest of the page:
+//
+//	t := template.Must(report.DefaultHTMLTemplate().Parse(`{{define "table"}}...{{end}}`))
+//	err := report.WriteHTML(w, "Monthly statement", tables, report.WithHTMLTemplate(t))
+func DefaultHTMLTemplate() *template.Template {
+	// html/template can't clone templates after they've been executed, so parse
+	// a fresh one.
+	return template.Must(template.New("report").Parse(defaultHTML))
+}
+
+// HTMLPage is the data HTML report templates are executed with.
+type HTMLPage struct {
+	Title      string
+	Stylesheet template.CSS
+	Tables     []HTMLTable
+}
+
+// HTMLTable is a Table prepared for an HTML template.
+type HTMLTable struct {
+	Title  string
+	Header []HTMLCell
+	Rows   [][]HTMLCell
+}
+
+// HTMLCell is a single table cell. Its Align renders as "left" or "right",
+// suitable for use as a CSS class.
+type HTMLCell struct {
+	Text  string
+	Align Alignment
+}
+
+func (a Alignment) String() string {
+	switch a {
+	case AlignLeft:
+		return "left"
+	case AlignRight:
+		return "right"
+	default:
+		return fmt.Sprintf("Alignment(%d)", int(a))
+	}
+}
+
+type htmlOpts struct {
+	tmpl       *template.Template
+	stylesheet *string
+}
+
+// WithHTMLTemplate renders the page with the given template, which must define
+// a "page" template, see DefaultHTMLTemplate.
+func WithHTMLTemplate(t *template.Template) HTMLOption {
+	return func(o *htmlOpts) {
+		o.tmpl = t
+	}
+}
+
+// WithStylesheet replaces DefaultStylesheet with the given CSS. The CSS is
+// trusted, and included in the page as-is.
+func WithStylesheet(css string) HTMLOption {
+	return func(o *htmlOpts) {
+		o.stylesheet = &css
+	}
+}
+
+type HTMLOption func(*htmlOpts)
+
+// WriteHTML renders the given tables as a standalone HTML page with the given
+// title.
+func WriteHTML(w io.Writer, title string, tables []*Table, opts ...HTMLOption) error {
+	o := &htmlOpts{tmpl: defaultHTMLTemplate}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	page := &HTMLPage{Title: title, Stylesheet: template.CSS(DefaultStylesheet)}
+	if o.stylesheet != nil {
+		page.Stylesheet = template.CSS(*o.stylesheet)
+	}
+	for _, t := range tables {
+		ht := HTMLTable{Title: t.Title}
+		for _, c := range t.Columns {
+			ht.Header = append(ht.Header, HTMLCell{Text: c.Header, Align: c.Align})
+		}
+		for _, row := range t.Rows {
+			cells := make([]HTMLCell, len(t.Columns))
+			for i, c := range t.Columns {
+				cells[i] = HTMLCell{Text: cellAt(row, i), Align: c.Align}
+			}
+			ht.Rows = append(ht.Rows, cells)
+		}
+		page.Tables = append(page.Tables, ht)
+	}
+
+	bw := bufio.NewWriter(w)
+	if err := o.tmpl.ExecuteTemplate(bw, "page", page); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,56 @@
+package report
+
+import (
+	"html/template"
+	"strings"
+	"testing"
+)
+
+func TestWriteHTML(t *testing.T) {
+	var sb strings.Builder
+	if err := WriteHTML(&sb, "January <statement>", []*Table{testTable()}); err != nil {
+		t.Fatalf("WriteHTML: %v", err)
+	}
+	got := sb.String()
+
+	for _, want := range []string{
+		"<title>January &lt;statement&gt;</title>",
+		"th, td {",
+		"<h2>Check register</h2>",
+		`<tr><th class="right">Number</th><th class="left">Date</th><th class="left">Payee</th><th class="right">Amount</th><th class="left">Memo</th></tr>`,
+		`<tr><td class="right">1003</td><td class="left">2023-01-09</td><td class="left">Gusto</td><td class="right">$1,234.50</td><td class="left">Payroll | Jan</td></tr>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("WriteHTML() output doesn't contain %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestWriteHTMLOverrides(t *testing.T) {
+	tmpl := template.Must(DefaultHTMLTemplate().Parse(`{{define "table"}}<p>{{.Title}}: {{len .Rows}} rows</p>{{end}}`))
+
+	var sb strings.Builder
+	err := WriteHTML(&sb, "Statement", []*Table{testTable()}, WithHTMLTemplate(tmpl), WithStylesheet("body { color: red; }"))
+	if err != nil {
+		t.Fatalf("WriteHTML: %v", err)
+	}
+	got := sb.String()
+
+	for _, want := range []string{"<p>Check register: 3 rows</p>", "body { color: red; }"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("WriteHTML() output doesn't contain %q, got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "<table>") || strings.Contains(got, "th, td") {
+		t.Errorf("WriteHTML() output includes default table markup or styles:\n%s", got)
+	}
+
+	// Overriding a copy doesn't affect the default.
+	sb.Reset()
+	if err := WriteHTML(&sb, "Statement", []*Table{testTable()}); err != nil {
+		t.Fatalf("WriteHTML: %v", err)
+	}
+	if !strings.Contains(sb.String(), "<table>") {
+		t.Errorf("default WriteHTML() output doesn't include a table:\n%s", sb.String())
+	}
+}
@@ -0,0 +1,200 @@
+package report
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// PDFEngine renders report tables as a PDF document. The built-in engine is
+// SimplePDF, which only needs the standard library. Deployments that need
+// richer output can plug in their own, e.g. one that converts the output of
+// WriteHTML with a headless browser.
+type PDFEngine interface {
+	RenderPDF(w io.Writer, title string, tables []*Table) error
+}
+
+// WritePDF renders the given tables as a PDF document with the given title,
+// using engine, or a zero SimplePDF if engine is nil.
+func WritePDF(w io.Writer, title string, tables []*Table, engine PDFEngine) error {
+	if engine == nil {
+		engine = SimplePDF{}
+	}
+	return engine.RenderPDF(w, title, tables)
+}
+
+// SimplePDF is a PDFEngine that lays out tables as they're rendered by
+// WriteText, in a monospaced font on US Letter pages, with page numbers. The
+// font is shrunk as needed to fit the widest table on the page. Characters
+// outside of the Windows-1252 character set are rendered as '?'.
+type SimplePDF struct {
+	// FontSize is the largest font size to use, in points. It defaults to 9.
+	FontSize float64
+	// Landscape lays out pages in landscape orientation, for wide tables.
+	Landscape bool
+}
+
+const (
+	pdfPageWidth  = 612 // US Letter, in points.
+	pdfPageHeight = 792
+	pdfMargin     = 54
+	pdfMinFont    = 5
+	// courierWidth is the width of every glyph in Courier, relative to the font
+	// size.
+	courierWidth = 0.6
+)
+
+func (s SimplePDF) RenderPDF(w io.Writer, title string, tables []*Table) error {
+	var lines []string
+	if title != "" {
+		lines = append(lines, title, "")
+	}
+	for i, t := range tables {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		var buf bytes.Buffer
+		if err := WriteText(&buf, t); err != nil {
+			return fmt.Errorf("failed to render table %d: %w", i, err)
+		}
+		lines = append(lines, strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")...)
+	}
+
+	width, height := float64(pdfPageWidth), float64(pdfPageHeight)
+	if s.Landscape {
+		width, height = height, width
+	}
+	size := s.FontSize
+	if size <= 0 {
+		size = 9
+	}
+	widest := 0
+	for _, l := range lines {
+		widest = max(widest, utf8.RuneCountInString(l))
+	}
+	if widest > 0 {
+		size = math.Max(pdfMinFont, math.Min(size, (width-2*pdfMargin)/(courierWidth*float64(widest))))
+	}
+	leading := size * 1.2
+	perPage := max(1, int((height-2*pdfMargin)/leading))
+
+	var pages [][]string
+	for len(lines) > perPage {
+		pages = append(pages, lines[:perPage])
+		lines = lines[perPage:]
+	}
+	pages = append(pages, lines)
+
+	pw := &pdfWriter{w: bufio.NewWriter(w)}
+	pw.printf("%%PDF-1.4\n%%\xe2\xe3\xcf\xd3\n")
+
+	// Objects 1-4 are fixed, followed by a page and content stream object for
+	// each page.
+	const catalog, pagesObj, font, info = 1, 2, 3, 4
+	pageObj := func(i int) int { return 5 + 2*i }
+
+	pw.object(catalog, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pagesObj))
+	var kids []string
+	for i := range pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", pageObj(i)))
+	}
+	pw.object(pagesObj, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d /MediaBox [0 0 %g %g] >>", strings.Join(kids, " "), len(pages), width, height))
+	pw.object(font, "<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+	pw.object(info, fmt.Sprintf("<< /Title %s /Producer (github.com/Silicon-Ally/aplos/report) >>", pdfString(title)))
+
+	for i, page := range pages {
+		var content bytes.Buffer
+		fmt.Fprintf(&content, "BT\n/F1 %.2f Tf\n%.2f TL\n%d %.2f Td\n", size, leading, pdfMargin, height-pdfMargin-size)
+		for _, l := range page {
+			fmt.Fprintf(&content, "%s Tj T*\n", pdfString(l))
+		}
+		content.WriteString("ET\n")
+		footer := fmt.Sprintf("Page %d of %d", i+1, len(pages))
+		fmt.Fprintf(&content, "BT\n/F1 %.2f Tf\n%.2f %.2f Td\n%s Tj\nET\n",
+			size, width-pdfMargin-courierWidth*size*float64(len(footer)), float64(pdfMargin)/2, pdfString(footer))
+
+		pw.object(pageObj(i), fmt.Sprintf("<< /Type /Page /Parent %d 0 R /Resources << /Font << /F1 %d 0 R >> >> /Contents %d 0 R >>", pagesObj, font, pageObj(i)+1))
+		pw.object(pageObj(i)+1, fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()))
+	}
+
+	xref := pw.n
+	pw.printf("xref\n0 %d\n0000000000 65535 f \n", len(pw.offsets)+1)
+	for _, off := range pw.offsets {
+		pw.printf("%010d 00000 n \n", off)
+	}
+	pw.printf("trailer\n<< /Size %d /Root %d 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(pw.offsets)+1, catalog, info, xref)
+
+	if pw.err != nil {
+		return pw.err
+	}
+	return pw.w.Flush()
+}
+
+// pdfWriter writes PDF objects, tracking their byte offsets for the
+// cross-reference table.
+type pdfWriter struct {
+	w       *bufio.Writer
+	n       int
+	offsets []int
+	err     error
+}
+
+func (p *pdfWriter) printf(format string, args ...interface{}) {
+	if p.err != nil {
+		return
+	}
+	n, err := fmt.Fprintf(p.w, format, args...)
+	p.n += n
+	p.err = err
+}
+
+// object writes object number id, which must be one more than the previous
+// object's.
+func (p *pdfWriter) object(id int, body string) {
+	p.offsets = append(p.offsets, p.n)
+	p.printf("%d 0 obj\n%s\nendobj\n", id, body)
+}
+
+// pdfString encodes s as a PDF literal string in WinAnsiEncoding.
+func pdfString(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('(')
+	for _, r := range s {
+		b, ok := winAnsi(r)
+		switch {
+		case !ok:
+			sb.WriteByte('?')
+		case b == '(' || b == ')' || b == '\\':
+			sb.WriteByte('\\')
+			sb.WriteByte(b)
+		case b < 0x20 || b >= 0x7f:
+			fmt.Fprintf(&sb, "\\%03o", b)
+		default:
+			sb.WriteByte(b)
+		}
+	}
+	sb.WriteByte(')')
+	return sb.String()
+}
+
+// winAnsiExtra maps the characters Windows-1252 places in 0x80-0x9f.
+var winAnsiExtra = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87,
+	'ˆ': 0x88, '‰': 0x89, 'Š': 0x8a, '‹': 0x8b, 'Œ': 0x8c, 'Ž': 0x8e, '‘': 0x91,
+	'’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '˜': 0x98,
+	'™': 0x99, 'š': 0x9a, '›': 0x9b, 'œ': 0x9c, 'ž': 0x9e, 'Ÿ': 0x9f,
+}
+
+func winAnsi(r rune) (byte, bool) {
+	if b, ok := winAnsiExtra[r]; ok {
+		return b, true
+	}
+	if r < 0x80 || (r >= 0xa0 && r <= 0xff) {
+		return byte(r), true
+	}
+	return 0, false
+}
@@ -0,0 +1,63 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestSimplePDF(t *testing.T) {
+	tables := []*Table{testTable()}
+	// Add enough rows to span multiple pages.
+	for i := 0; i < 100; i++ {
+		tables[0].Rows = append(tables[0].Rows, []string{strconv.Itoa(2000 + i), "", "", "", "€5 (cash)"})
+	}
+
+	var buf bytes.Buffer
+	if err := WritePDF(&buf, "Board packet", tables, nil); err != nil {
+		t.Fatalf("WritePDF: %v", err)
+	}
+	pdf := buf.Bytes()
+
+	if !bytes.HasPrefix(pdf, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(pdf, []byte("%%EOF\n")) {
+		t.Errorf("output isn't framed like a PDF: %q...%q", pdf[:10], pdf[len(pdf)-10:])
+	}
+	for _, want := range []string{
+		"/Title (Board packet)",
+		"(Check register) Tj",
+		`(  2000                                          \2005 \(cash\)) Tj`,
+		"(Page 1 of 2) Tj",
+		"(Page 2 of 2) Tj",
+	} {
+		if !bytes.Contains(pdf, []byte(want)) {
+			t.Errorf("output doesn't contain %q", want)
+		}
+	}
+
+	// Check that the cross-reference table points at each object.
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(pdf)
+	if m == nil {
+		t.Fatal("no startxref found")
+	}
+	xref, _ := strconv.Atoi(string(m[1]))
+	lines := strings.Split(string(pdf[xref:]), "\n")
+	if lines[0] != "xref" {
+		t.Fatalf("startxref points at %q, want xref", lines[0])
+	}
+	var n int
+	if _, err := fmt.Sscanf(lines[1], "0 %d", &n); err != nil {
+		t.Fatalf("failed to parse xref header %q: %v", lines[1], err)
+	}
+	for id := 1; id < n; id++ {
+		off, err := strconv.Atoi(strings.Fields(lines[2+id])[0])
+		if err != nil {
+			t.Fatalf("failed to parse xref entry %q: %v", lines[2+id], err)
+		}
+		if want := strconv.Itoa(id) + " 0 obj"; !bytes.HasPrefix(pdf[off:], []byte(want)) {
+			t.Errorf("xref entry %d points at %q, want %q", id, pdf[off:off+len(want)], want)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package report
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Silicon-Ally/aplos"
+)
+
+// PositivePayField is a field of a positive pay record.
+type PositivePayField string
+
+const (
+	// PayAccount is the bank account number, from PositivePayLayout.Account.
+	PayAccount PositivePayField = "account"
+	// PayCheckNumber is the check number.
+	PayCheckNumber PositivePayField = "check_number"
+	// PayDate is the issue date, formatted with PositivePayLayout.DateFormat.
+	PayDate PositivePayField = "date"
+	// PayAmount is the check amount, see PositivePayLayout.AmountInCents.
+	PayAmount PositivePayField = "amount"
+	// PayPayee is the payee name.
+	PayPayee PositivePayField = "payee"
+)
+
+// PositivePayColumn is a single column of a PositivePayLayout.
+type PositivePayColumn struct {
+	Field PositivePayField `json:"field"`
+	// Header is the column's heading, if the layout has a header row. It
+	// defaults to the field name.
+	Header string `json:"header"`
+	// Width is the column's width in characters for fixed-width layouts, and
+	// is ignored for delimited ones. Numbers are right-aligned and padded with
+	// zeros, and text is left-aligned, padded with spaces, and truncated to
+	// fit.
+	Width int `json:"width"`
+}
+
+// PositivePayLayout describes a bank's positive pay file format. It's
+// typically loaded from a JSON config file, since each bank has its own.
+type PositivePayLayout struct {
+	// Account is the bank account number the checks are drawn on.
+	Account string `json:"account"`
+	// Columns lists the fields of each record, in order.
+	Columns []PositivePayColumn `json:"columns"`
+	// Delimiter separates fields, e.g. ",". Empty means the file is fixed
+	// width, see PositivePayColumn.Width.
+	Delimiter string `json:"delimiter"`
+	// Header adds a row of column headings before the records. It's ignored for
+	// fixed-width layouts.
+	Header bool `json:"header"`
+	// DateFormat is the layout of dates, as for time.Time.Format. It defaults
+	// to "01/02/2006".
+	DateFormat string `json:"date_format"`
+	// AmountInCents writes amounts as a whole number of cents, e.g. "123456"
+	// for $1,234.56, instead of "1234.56".
+	AmountInCents bool `json:"amount_in_cents"`
+	// CRLF ends records with "\r\n" instead of "\n".
+	CRLF bool `json:"crlf"`
+}
+
+// PositivePayCSV is a common delimited layout, with a header row.
+var PositivePayCSV = PositivePayLayout{
+	Columns: []PositivePayColumn{
+		{Field: PayAccount, Header: "Account"},
+		{Field: PayCheckNumber, Header: "Check Number"},
+		{Field: PayDate, Header: "Issue Date"},
+		{Field: PayAmount, Header: "Amount"},
+		{Field: PayPayee, Header: "Payee"},
+	},
+	Delimiter: ",",
+	Header:    true,
+}
+
+// WritePositivePay writes a positive pay file listing the register's checks in
+// the given layout, for the bank to match presented checks against. It's an
+// error if the layout includes the payee and a check doesn't have one, since
+// banks reject such records, or if a value doesn't fit a fixed-width column.
+// Duplicate check numbers are written as is, so review the register's
+// Duplicates first.
+func (cr *CheckRegister) WritePositivePay(w io.Writer, l PositivePayLayout) error {
+	if len(l.Columns) == 0 {
+		return errors.New("positive pay layout has no columns")
+	}
+	for _, col := range l.Columns {
+		switch col.Field {
+		case PayAccount, PayCheckNumber, PayDate, PayAmount, PayPayee:
+		default:
+			return fmt.Errorf("unknown positive pay field %q", col.Field)
+		}
+		if l.Delimiter == "" && col.Width <= 0 {
+			return fmt.Errorf("column %q of a fixed-width layout needs a width", col.Field)
+		}
+	}
+
+	var rows [][]string
+	if l.Header && l.Delimiter != "" {
+		var row []string
+		for _, col := range l.Columns {
+			h := col.Header
+			if h == "" {
+				h = string(col.Field)
+			}
+			row = append(row, h)
+		}
+		rows = append(rows, row)
+	}
+	for _, c := range cr.Checks {
+		row, err := l.record(c)
+		if err != nil {
+			return fmt.Errorf("check %d: %w", c.Number, err)
+		}
+		rows = append(rows, row)
+	}
+
+	if l.Delimiter != "" {
+		comma, n := utf8.DecodeRuneInString(l.Delimiter)
+		if n != len(l.Delimiter) {
+			return fmt.Errorf("delimiter %q must be a single character", l.Delimiter)
+		}
+		cw := csv.NewWriter(w)
+		cw.Comma = comma
+		cw.UseCRLF = l.CRLF
+		if err := cw.WriteAll(rows); err != nil {
+			return fmt.Errorf("failed to write positive pay file: %w", err)
+		}
+		return nil
+	}
+
+	eol := "\n"
+	if l.CRLF {
+		eol = "\r\n"
+	}
+	bw := bufio.NewWriter(w)
+	for _, row := range rows {
+		bw.WriteString(strings.Join(row, "") + eol)
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write positive pay file: %w", err)
+	}
+	return nil
+}
+
+// record returns the fields of a check's record, padded to their column widths
+// for fixed-width layouts.
+func (l PositivePayLayout) record(c Check) ([]string, error) {
+	row := make([]string, 0, len(l.Columns))
+	for _, col := range l.Columns {
+		var (
+			v       string
+			numeric bool
+		)
+		switch col.Field {
+		case PayAccount:
+			v, numeric = l.Account, true
+		case PayCheckNumber:
+			v, numeric = strconv.Itoa(c.Number), true
+		case PayDate:
+			format := l.DateFormat
+			if format == "" {
+				format = "01/02/2006"
+			}
+			v = c.Date.Time().Format(format)
+		case PayAmount:
+			v, numeric = l.amount(c.Amount), true
+		case PayPayee:
+			if c.Payee == "" {
+				return nil, errors.New("no payee")
+			}
+			v = c.Payee
+		}
+		if l.Delimiter == "" {
+			var err error
+			if v, err = pad(v, col.Width, numeric); err != nil {
+				return nil, fmt.Errorf("%s: %w", col.Field, err)
+			}
+		}
+		row = append(row, v)
+	}
+	return row, nil
+}
+
+func (l PositivePayLayout) amount(amt aplos.Amount) string {
+	cents := amt.Abs().Cents()
+	if l.AmountInCents {
+		return strconv.FormatInt(cents, 10)
+	}
+	return fmt.Sprintf("%d.%02d", cents/100, cents%100)
+}
+
+// pad fits v to width characters. Numbers are zero-padded on the left and are
+// never truncated; text is padded with spaces on the right and truncated.
+func pad(v string, width int, numeric bool) (string, error) {
+	n := utf8.RuneCountInString(v)
+	switch {
+	case n == width:
+		return v, nil
+	case n < width && numeric:
+		return strings.Repeat("0", width-n) + v, nil
+	case n < width:
+		return v + strings.Repeat(" ", width-n), nil
+	case numeric:
+		return "", fmt.Errorf("%q is longer than %d characters", v, width)
+	default:
+		return string([]rune(v)[:width]), nil
+	}
+}
@@ -0,0 +1,111 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Silicon-Ally/aplos"
+)
+
+func TestWritePositivePay(t *testing.T) {
+	date := aplos.Date{Year: 2023, Month: time.March, Day: 1}
+	cr := &CheckRegister{
+		Checks: []Check{
+			{Number: 1001, Date: date, Amount: 1234_56, Payee: "Acme Properties, LLC"},
+			{Number: 1002, Date: date, Amount: 20_00, Payee: "Grace Hopper"},
+		},
+	}
+
+	csvLayout := PositivePayCSV
+	csvLayout.Account = "123456789"
+
+	fixed := PositivePayLayout{
+		Account: "123456789",
+		Columns: []PositivePayColumn{
+			{Field: PayAccount, Width: 12},
+			{Field: PayCheckNumber, Width: 8},
+			{Field: PayDate, Width: 6},
+			{Field: PayAmount, Width: 10},
+			{Field: PayPayee, Width: 10},
+		},
+		DateFormat:    "010206",
+		AmountInCents: true,
+		CRLF:          true,
+	}
+
+	tests := []struct {
+		desc   string
+		layout PositivePayLayout
+		want   string
+	}{
+		{
+			desc:   "csv",
+			layout: csvLayout,
+			want: "Account,Check Number,Issue Date,Amount,Payee\n" +
+				"123456789,1001,03/01/2023,1234.56,\"Acme Properties, LLC\"\n" +
+				"123456789,1002,03/01/2023,20.00,Grace Hopper\n",
+		},
+		{
+			desc:   "fixed width",
+			layout: fixed,
+			// Fields are concatenated, split here for readability.
+			want: "000123456789" + "00001001" + "030123" + "0000123456" + "Acme Prope" + "\r\n" +
+				"000123456789" + "00001002" + "030123" + "0000002000" + "Grace Hopp" + "\r\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			var b strings.Builder
+			if err := cr.WritePositivePay(&b, test.layout); err != nil {
+				t.Fatalf("WritePositivePay: %v", err)
+			}
+			if got := b.String(); got != test.want {
+				t.Errorf("WritePositivePay() =\n%q\nwant\n%q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestWritePositivePayErrors(t *testing.T) {
+	date := aplos.Date{Year: 2023, Month: time.March, Day: 1}
+	tests := []struct {
+		desc   string
+		checks []Check
+		layout PositivePayLayout
+	}{
+		{
+			desc:   "missing payee",
+			checks: []Check{{Number: 1001, Date: date, Amount: 10_00}},
+			layout: PositivePayCSV,
+		},
+		{
+			desc:   "amount too wide",
+			checks: []Check{{Number: 1001, Date: date, Amount: 1000_00, Payee: "Acme"}},
+			layout: PositivePayLayout{Columns: []PositivePayColumn{{Field: PayAmount, Width: 5}}},
+		},
+		{
+			desc:   "no width",
+			checks: []Check{{Number: 1001, Date: date, Amount: 10_00, Payee: "Acme"}},
+			layout: PositivePayLayout{Columns: []PositivePayColumn{{Field: PayCheckNumber}}},
+		},
+		{
+			desc:   "unknown field",
+			layout: PositivePayLayout{Columns: []PositivePayColumn{{Field: "memo"}}, Delimiter: ","},
+		},
+		{
+			desc:   "no columns",
+			layout: PositivePayLayout{Delimiter: ","},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			cr := &CheckRegister{Checks: test.checks}
+			if err := cr.WritePositivePay(&strings.Builder{}, test.layout); err == nil {
+				t.Error("WritePositivePay returned no error, want one")
+			}
+		})
+	}
+}
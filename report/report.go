@@ -0,0 +1,2 @@
+// Package report generates reports from data loaded with the aplos package.
+package report
@@ -0,0 +1,276 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Silicon-Ally/aplos"
+)
+
+// earliestDate is the start of the range when loading an account or fund's full
+// history, since listing transactions without a start date may be limited by
+// the client's default lookback.
+var earliestDate = aplos.Date{Year: 1900, Month: time.January, Day: 1}
+
+// Statement lists the activity in part of the ledger over a period, with a
+// running balance.
+type Statement struct {
+	// Start and End are the first and last days of the statement.
+	Start, End aplos.Date
+	// Opening is the balance at the start of the first day.
+	Opening aplos.Amount
+	// Entries lists the activity in the period, ordered by date.
+	Entries []StatementEntry
+	// Closing is the balance at the end of the last day.
+	Closing aplos.Amount
+}
+
+// StatementEntry is the activity of a single transaction in a Statement.
+type StatementEntry struct {
+	Date          aplos.Date
+	TransactionID int
+	Memo          string
+	// Amount is the net amount of the transaction's lines included in the
+	// statement.
+	Amount aplos.Amount
+	// Balance is the running balance after this entry.
+	Balance aplos.Amount
+}
+
+// newStatement builds a Statement from the given transactions, which must
+// include their lines. amount returns the amount of a line included in the
+// statement, and false if the line isn't included. Transactions before start
+// count toward the opening balance, and those after end are ignored.
+func newStatement(txns []aplos.Transaction, start, end aplos.Date, amount func(aplos.TransactionLine) (aplos.Amount, bool)) Statement {
+	s := Statement{Start: start, End: end}
+	for _, t := range txns {
+		var sum aplos.Amount
+		included := false
+		for _, l := range t.Lines {
+			if v, ok := amount(l); ok {
+				sum += v
+				included = true
+			}
+		}
+		if !included {
+			continue
+		}
+		switch {
+		case t.Date.Before(start):
+			s.Opening += sum
+		case !t.Date.After(end):
+			s.Entries = append(s.Entries, StatementEntry{
+				Date:          t.Date,
+				TransactionID: t.ID,
+				Memo:          t.Memo,
+				Amount:        sum,
+			})
+		}
+	}
+
+	sort.SliceStable(s.Entries, func(i, j int) bool {
+		return s.Entries[i].Date.Before(s.Entries[j].Date)
+	})
+	balance := s.Opening
+	for i := range s.Entries {
+		balance += s.Entries[i].Amount
+		s.Entries[i].Balance = balance
+	}
+	s.Closing = balance
+	return s
+}
+
+// table lays out the statement with the given title, with rows for the
+// opening balance, each entry, and the closing balance.
+func (s *Statement) table(title string, f CurrencyFormat) *Table {
+	t := &Table{
+		Title: title,
+		Columns: []Column{
+			{Header: "Date"},
+			{Header: "ID", Align: AlignRight},
+			{Header: "Memo"},
+			{Header: "Amount", Align: AlignRight},
+			{Header: "Balance", Align: AlignRight},
+		},
+	}
+	t.Rows = append(t.Rows, []string{s.Start.String(), "", "Opening balance", "", f.FormatAmount(s.Opening)})
+	for _, e := range s.Entries {
+		t.Rows = append(t.Rows, []string{e.Date.String(), strconv.Itoa(e.TransactionID), e.Memo, f.FormatAmount(e.Amount), f.FormatAmount(e.Balance)})
+	}
+	t.Rows = append(t.Rows, []string{s.End.String(), "", "Closing balance", "", f.FormatAmount(s.Closing)})
+	return t
+}
+
+// AccountStatement lists the activity in a single account over a period, e.g.
+// everything charged to Travel in a quarter.
+//
+// Amounts and balances are in the account's normal balance, so they're
+// debit-positive for asset and expense accounts, and credit-positive for
+// liability, equity, and income accounts, like donations.
+type AccountStatement struct {
+	Account aplos.Account
+	Statement
+}
+
+// LoadAccountStatement loads the account with the given number and its
+// transactions, and builds an AccountStatement for the period from start to
+// end, inclusive.
+//
+// For balance sheet accounts, the opening balance includes the account's full
+// history. Income and expense accounts are closed out at the end of each
+// year, so their opening balance is the activity since the start of the
+// calendar year, i.e. the year-to-date total.
+//
+// Transactions are listed without their lines, so this makes one request per
+// transaction to load them.
+func LoadAccountStatement(ctx context.Context, c *aplos.Client, accountNumber int, start, end aplos.Date) (*AccountStatement, error) {
+	acct, err := c.Account(ctx, accountNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load account: %w", err)
+	}
+
+	from := earliestDate
+	if !balanceSheetCategory(acct.Category) {
+		from = aplos.Date{Year: start.Year, Month: time.January, Day: 1}
+	}
+	txns, err := c.Transactions(ctx,
+		aplos.WithAccountNumber(accountNumber),
+		aplos.WithRangeStart(from.Year, from.Month, from.Day),
+		aplos.WithRangeEnd(end.Year, end.Month, end.Day),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load transactions: %w", err)
+	}
+	full, err := c.TransactionDetails(ctx, transactionIDs(txns))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load transaction lines: %w", err)
+	}
+	return NewAccountStatement(*acct, full, start, end), nil
+}
+
+// NewAccountStatement builds an AccountStatement for the given account from
+// its transactions, which must include their lines. Transactions before start
+// count toward the opening balance, and those after end are ignored, so the
+// caller decides how much history the opening balance covers.
+func NewAccountStatement(acct aplos.Account, txns []aplos.Transaction, start, end aplos.Date) *AccountStatement {
+	sign := aplos.Amount(1)
+	if creditNormal(acct.Category) {
+		sign = -1
+	}
+	s := newStatement(txns, start, end, func(l aplos.TransactionLine) (aplos.Amount, bool) {
+		return sign * l.Amount, l.Account.AccountNumber == acct.AccountNumber
+	})
+	return &AccountStatement{Account: acct, Statement: s}
+}
+
+// Table lays out the statement with one row per entry, between rows for the
+// opening and closing balances.
+func (s *AccountStatement) Table(f CurrencyFormat) *Table {
+	return s.table(fmt.Sprintf("%d %s", s.Account.AccountNumber, s.Account.Name), f)
+}
+
+// creditNormal reports whether accounts in the given category normally carry
+// a credit balance.
+func creditNormal(category string) bool {
+	switch strings.ToLower(category) {
+	case "liability", "equity", "income":
+		return true
+	default:
+		return false
+	}
+}
+
+// balanceSheetCategory reports whether accounts in the given category carry
+// their balance from year to year, unlike income and expense accounts.
+func balanceSheetCategory(category string) bool {
+	switch strings.ToLower(category) {
+	case "income", "expense":
+		return false
+	default:
+		return true
+	}
+}
+
+func transactionIDs(txns []aplos.Transaction) []int {
+	ids := make([]int, len(txns))
+	for i, t := range txns {
+		ids[i] = t.ID
+	}
+	return ids
+}
+
+// FundStatement lists the activity in a single fund over a period, across all
+// accounts, e.g. for reporting on a restricted grant.
+//
+// The fund's balance is its net assets: its equity, plus income, less
+// expenses. Amounts are credit-positive, so gifts to the fund increase its
+// balance and spending from it decreases it. Transactions that touch the fund
+// without changing its balance, like a transfer between bank accounts, are
+// listed with a zero amount.
+type FundStatement struct {
+	Fund aplos.Fund
+	Statement
+}
+
+// LoadFundStatement loads the fund with the given ID, the chart of accounts,
+// and all transactions up to end, and builds a FundStatement for the period
+// from start to end, inclusive.
+//
+// Transactions can't be listed by fund, and a fund's balance carries over
+// from year to year, so this loads the lines of every transaction in the
+// organization's history, making one request per transaction.
+func LoadFundStatement(ctx context.Context, c *aplos.Client, fundID int, start, end aplos.Date) (*FundStatement, error) {
+	fund, err := c.Fund(ctx, fundID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fund: %w", err)
+	}
+	accts, err := c.Accounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load accounts: %w", err)
+	}
+	txns, err := c.Transactions(ctx,
+		aplos.WithRangeStart(earliestDate.Year, earliestDate.Month, earliestDate.Day),
+		aplos.WithRangeEnd(end.Year, end.Month, end.Day),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load transactions: %w", err)
+	}
+	full, err := c.TransactionDetails(ctx, transactionIDs(txns))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load transaction lines: %w", err)
+	}
+	return NewFundStatement(*fund, full, accts, start, end), nil
+}
+
+// NewFundStatement builds a FundStatement for the given fund from
+// transactions, which must include their lines, and the chart of accounts,
+// which is used to classify lines by category. Transactions before start
+// count toward the opening balance, and those after end are ignored.
+func NewFundStatement(fund aplos.Fund, txns []aplos.Transaction, accts []aplos.Account, start, end aplos.Date) *FundStatement {
+	netAssets := make(map[int]bool)
+	for _, a := range accts {
+		if !balanceSheetCategory(a.Category) || strings.EqualFold(a.Category, "equity") {
+			netAssets[a.AccountNumber] = true
+		}
+	}
+	s := newStatement(txns, start, end, func(l aplos.TransactionLine) (aplos.Amount, bool) {
+		if l.Fund.ID != fund.ID {
+			return 0, false
+		}
+		if !netAssets[l.Account.AccountNumber] {
+			return 0, true
+		}
+		return -l.Amount, true
+	})
+	return &FundStatement{Fund: fund, Statement: s}
+}
+
+// Table lays out the statement with one row per entry, between rows for the
+// opening and closing balances.
+func (s *FundStatement) Table(f CurrencyFormat) *Table {
+	return s.table(s.Fund.Name, f)
+}
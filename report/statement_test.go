@@ -0,0 +1,125 @@
+package report
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Silicon-Ally/aplos"
+)
+
+func TestNewAccountStatement(t *testing.T) {
+	d := func(month time.Month, day int) aplos.Date { return aplos.Date{Year: 2023, Month: month, Day: day} }
+	travel := aplos.Account{AccountNumber: 5100, Name: "Travel", Category: "expense"}
+	checking := aplos.Account{AccountNumber: 1000, Name: "Checking", Category: "asset"}
+	donations := aplos.Account{AccountNumber: 4000, Name: "Donations", Category: "income"}
+	line := func(acct aplos.Account, amt aplos.Amount) aplos.TransactionLine {
+		return aplos.TransactionLine{Account: acct, Amount: amt}
+	}
+
+	txns := []aplos.Transaction{
+		{ID: 1, Date: d(time.February, 3), Memo: "Flights", Lines: []aplos.TransactionLine{line(travel, 400_00), line(checking, -400_00)}},
+		{ID: 2, Date: d(time.May, 20), Memo: "Hotel", Lines: []aplos.TransactionLine{line(travel, 250_25), line(checking, -250_25)}},
+		{ID: 3, Date: d(time.April, 2), Memo: "Gift", Lines: []aplos.TransactionLine{line(donations, -1000_00), line(checking, 1000_00)}},
+		{ID: 4, Date: d(time.April, 10), Memo: "Refund", Lines: []aplos.TransactionLine{line(travel, -50_00), line(checking, 50_00)}},
+		{ID: 5, Date: d(time.July, 1), Memo: "Train", Lines: []aplos.TransactionLine{line(travel, 80_00), line(checking, -80_00)}},
+	}
+	start, end := d(time.April, 1), d(time.June, 30)
+
+	got := NewAccountStatement(travel, txns, start, end)
+	want := &AccountStatement{
+		Account: travel,
+		Statement: Statement{
+			Start:   start,
+			End:     end,
+			Opening: 400_00,
+			Entries: []StatementEntry{
+				{Date: d(time.April, 10), TransactionID: 4, Memo: "Refund", Amount: -50_00, Balance: 350_00},
+				{Date: d(time.May, 20), TransactionID: 2, Memo: "Hotel", Amount: 250_25, Balance: 600_25},
+			},
+			Closing: 600_25,
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewAccountStatement() = %+v, want %+v", got, want)
+	}
+
+	// Income accounts are credit-positive.
+	inc := NewAccountStatement(donations, txns, start, end)
+	if len(inc.Entries) != 1 || inc.Entries[0].Amount != 1000_00 || inc.Closing != 1000_00 {
+		t.Errorf("NewAccountStatement(donations) = %+v, want one entry of 1000", inc)
+	}
+}
+
+func TestAccountStatementTable(t *testing.T) {
+	d := func(day int) aplos.Date { return aplos.Date{Year: 2023, Month: time.April, Day: day} }
+	s := &AccountStatement{
+		Account: aplos.Account{AccountNumber: 5100, Name: "Travel"},
+		Statement: Statement{
+			Start:   d(1),
+			End:     d(30),
+			Opening: 400_00,
+			Entries: []StatementEntry{{Date: d(10), TransactionID: 4, Memo: "Refund", Amount: -50_00, Balance: 350_00}},
+			Closing: 350_00,
+		},
+	}
+
+	got := s.Table(USD)
+	if got.Title != "5100 Travel" {
+		t.Errorf("Title = %q, want %q", got.Title, "5100 Travel")
+	}
+	wantRows := [][]string{
+		{"2023-04-01", "", "Opening balance", "", "$400.00"},
+		{"2023-04-10", "4", "Refund", "-$50.00", "$350.00"},
+		{"2023-04-30", "", "Closing balance", "", "$350.00"},
+	}
+	if !reflect.DeepEqual(got.Rows, wantRows) {
+		t.Errorf("Rows = %q, want %q", got.Rows, wantRows)
+	}
+}
+
+func TestNewFundStatement(t *testing.T) {
+	d := func(month time.Month, day int) aplos.Date { return aplos.Date{Year: 2023, Month: month, Day: day} }
+	grant := aplos.Fund{ID: 2, Name: "Literacy Grant"}
+	general := aplos.Fund{ID: 1, Name: "General"}
+	accts := []aplos.Account{
+		{AccountNumber: 1000, Category: "asset"},
+		{AccountNumber: 1100, Category: "asset"},
+		{AccountNumber: 3000, Category: "equity"},
+		{AccountNumber: 4000, Category: "income"},
+		{AccountNumber: 5000, Category: "expense"},
+	}
+	line := func(acct int, fund aplos.Fund, amt aplos.Amount) aplos.TransactionLine {
+		return aplos.TransactionLine{Account: aplos.Account{AccountNumber: acct}, Fund: fund, Amount: amt}
+	}
+
+	txns := []aplos.Transaction{
+		{ID: 1, Date: d(time.January, 5), Memo: "Grant award", Lines: []aplos.TransactionLine{line(4000, grant, -10000_00), line(1000, grant, 10000_00)}},
+		{ID: 2, Date: d(time.February, 1), Memo: "Books", Lines: []aplos.TransactionLine{line(5000, grant, 1200_00), line(1000, grant, -1200_00)}},
+		{ID: 3, Date: d(time.February, 2), Memo: "Rent", Lines: []aplos.TransactionLine{line(5000, general, 900_00), line(1000, general, -900_00)}},
+		{ID: 4, Date: d(time.February, 10), Memo: "Transfer to savings", Lines: []aplos.TransactionLine{line(1100, grant, 5000_00), line(1000, grant, -5000_00)}},
+		{ID: 5, Date: d(time.March, 3), Memo: "Tutors", Lines: []aplos.TransactionLine{line(5000, grant, 2500_50), line(1100, grant, -2500_50)}},
+	}
+	start, end := d(time.February, 1), d(time.February, 28)
+
+	got := NewFundStatement(grant, txns, accts, start, end)
+	want := &FundStatement{
+		Fund: grant,
+		Statement: Statement{
+			Start:   start,
+			End:     end,
+			Opening: 10000_00,
+			Entries: []StatementEntry{
+				{Date: d(time.February, 1), TransactionID: 2, Memo: "Books", Amount: -1200_00, Balance: 8800_00},
+				{Date: d(time.February, 10), TransactionID: 4, Memo: "Transfer to savings", Amount: 0, Balance: 8800_00},
+			},
+			Closing: 8800_00,
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewFundStatement() = %+v, want %+v", got, want)
+	}
+	if got, want := got.Table(USD).Title, "Literacy Grant"; got != want {
+		t.Errorf("Table().Title = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,154 @@
+package report
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// Alignment is how cells in a Column are aligned.
+type Alignment int
+
+const (
+	AlignLeft Alignment = iota
+	AlignRight
+)
+
+// Column describes a single column of a Table.
+type Column struct {
+	Header string
+	Align  Alignment
+}
+
+// Table is a report laid out as rows of formatted cells, ready to be rendered
+// with a function like WriteText or WriteMarkdown.
+type Table struct {
+	Title   string
+	Columns []Column
+	// Rows holds one cell per column. Short rows are padded with empty cells.
+	Rows [][]string
+}
+
+// Tabler is implemented by reports that can be rendered as a Table, with
+// amounts formatted with the given CurrencyFormat.
+type Tabler interface {
+	Table(f CurrencyFormat) *Table
+}
+
+// WriteText renders t as an aligned, plain-text table for terminals, like:
+//
+//	Check register
+//
+//	Number  Date        Amount
+//	------  ----------  -------
+//	1001    2023-01-05  $100.00
+func WriteText(w io.Writer, t *Table) error {
+	bw := bufio.NewWriter(w)
+	if t.Title != "" {
+		fmt.Fprintf(bw, "%s\n\n", t.Title)
+	}
+
+	widths := t.widths(false)
+	header := make([]string, len(t.Columns))
+	rule := make([]string, len(t.Columns))
+	for i, c := range t.Columns {
+		header[i] = c.Header
+		rule[i] = strings.Repeat("-", widths[i])
+	}
+	writeTextRow(bw, t.Columns, widths, header)
+	writeTextRow(bw, t.Columns, widths, rule)
+	for _, row := range t.Rows {
+		writeTextRow(bw, t.Columns, widths, row)
+	}
+	return bw.Flush()
+}
+
+func writeTextRow(w *bufio.Writer, cols []Column, widths []int, row []string) {
+	var sb strings.Builder
+	for i, c := range cols {
+		if i > 0 {
+			sb.WriteString("  ")
+		}
+		cell := cellAt(row, i)
+		pad := strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell))
+		if c.Align == AlignRight {
+			sb.WriteString(pad + cell)
+		} else {
+			sb.WriteString(cell + pad)
+		}
+	}
+	fmt.Fprintln(w, strings.TrimRight(sb.String(), " "))
+}
+
+// WriteMarkdown renders t as a GitHub-flavored Markdown table, for pasting into
+// issue trackers and wikis. The title, if any, is rendered as a heading.
+func WriteMarkdown(w io.Writer, t *Table) error {
+	bw := bufio.NewWriter(w)
+	if t.Title != "" {
+		fmt.Fprintf(bw, "## %s\n\n", t.Title)
+	}
+
+	widths := t.widths(true)
+	header := make([]string, len(t.Columns))
+	rule := make([]string, len(t.Columns))
+	for i, c := range t.Columns {
+		header[i] = c.Header
+		// Markdown needs at least three dashes in the delimiter row.
+		n := max(widths[i], 3)
+		if c.Align == AlignRight {
+			rule[i] = strings.Repeat("-", n-1) + ":"
+		} else {
+			rule[i] = strings.Repeat("-", n)
+		}
+	}
+	writeMarkdownRow(bw, t.Columns, widths, header)
+	fmt.Fprintf(bw, "| %s |\n", strings.Join(rule, " | "))
+	for _, row := range t.Rows {
+		writeMarkdownRow(bw, t.Columns, widths, row)
+	}
+	return bw.Flush()
+}
+
+func writeMarkdownRow(w *bufio.Writer, cols []Column, widths []int, row []string) {
+	cells := make([]string, len(cols))
+	for i, c := range cols {
+		cell := escapeMarkdown(cellAt(row, i))
+		pad := strings.Repeat(" ", max(widths[i], 3)-utf8.RuneCountInString(cell))
+		if c.Align == AlignRight {
+			cells[i] = pad + cell
+		} else {
+			cells[i] = cell + pad
+		}
+	}
+	fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | "))
+}
+
+func escapeMarkdown(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// widths returns the width, in runes, of the widest cell in each column. If
+// markdown is true, cells are measured after escaping.
+func (t *Table) widths(markdown bool) []int {
+	widths := make([]int, len(t.Columns))
+	for i, c := range t.Columns {
+		widths[i] = utf8.RuneCountInString(c.Header)
+		for _, row := range t.Rows {
+			cell := cellAt(row, i)
+			if markdown {
+				cell = escapeMarkdown(cell)
+			}
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+	return widths
+}
+
+func cellAt(row []string, i int) string {
+	if i < len(row) {
+		return row[i]
+	}
+	return ""
+}
@@ -0,0 +1,75 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Silicon-Ally/aplos"
+)
+
+func testTable() *Table {
+	cr := &CheckRegister{
+		Checks: []Check{
+			{Number: 1001, Date: aplos.Date{Year: 2023, Month: time.January, Day: 5}, Payee: "Acme Properties", Amount: 100_00, Memo: "Rent"},
+			{Number: 1003, Date: aplos.Date{Year: 2023, Month: time.January, Day: 9}, Payee: "Gusto", Amount: 1234_50, Memo: "Payroll | Jan"},
+		},
+		Gaps: []NumberRange{{First: 1002, Last: 1002}},
+	}
+	return cr.Table(USD)
+}
+
+func TestWriteText(t *testing.T) {
+	var sb strings.Builder
+	if err := WriteText(&sb, testTable()); err != nil {
+		t.Fatalf("WriteText: %v", err)
+	}
+	want := `Check register
+
+Number  Date        Payee               Amount  Memo
+------  ----------  ---------------  ---------  -------------
+  1001  2023-01-05  Acme Properties    $100.00  Rent
+  1003  2023-01-09  Gusto            $1,234.50  Payroll | Jan
+  1002                                          Missing
+`
+	if got := sb.String(); got != want {
+		t.Errorf("WriteText() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	var sb strings.Builder
+	if err := WriteMarkdown(&sb, testTable()); err != nil {
+		t.Fatalf("WriteMarkdown: %v", err)
+	}
+	want := `## Check register
+
+| Number | Date       | Payee           |    Amount | Memo           |
+| -----: | ---------- | --------------- | --------: | -------------- |
+|   1001 | 2023-01-05 | Acme Properties |   $100.00 | Rent           |
+|   1003 | 2023-01-09 | Gusto           | $1,234.50 | Payroll \| Jan |
+|   1002 |            |                 |           | Missing        |
+`
+	if got := sb.String(); got != want {
+		t.Errorf("WriteMarkdown() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestTimeSeriesTable(t *testing.T) {
+	ts := &TimeSeries{
+		Labels: []string{"2023-01", "2023-02"},
+		Series: []Series{{Name: "Income", Values: []float64{10, 20}}},
+	}
+	var sb strings.Builder
+	if err := WriteText(&sb, ts.Table(USD)); err != nil {
+		t.Fatalf("WriteText: %v", err)
+	}
+	want := `Period   Income
+-------  ------
+2023-01  $10.00
+2023-02  $20.00
+`
+	if got := sb.String(); got != want {
+		t.Errorf("WriteText() =\n%s\nwant\n%s", got, want)
+	}
+}
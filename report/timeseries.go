@@ -0,0 +1,174 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Silicon-Ally/aplos"
+)
+
+// TimeSeries is chart-ready data, with one label per period and any number of
+// series with one value per label. It marshals to JSON like:
+//
+//	{"labels": ["2023-01", "2023-02"], "series": [{"name": "Income", "values": [10, 20]}]}
+type TimeSeries struct {
+	Labels []string `json:"labels"`
+	Series []Series `json:"series"`
+}
+
+// Series is a single named line or set of bars in a TimeSeries. Values are in
+// dollars, for charting libraries; they're summed exactly, as aplos.Amounts,
+// before being converted.
+type Series struct {
+	Name   string    `json:"name"`
+	Values []float64 `json:"values"`
+}
+
+// Transaction line amounts are signed, with debits positive and credits
+// negative, so e.g. income shows up as negative amounts on income account
+// lines. The functions below flip signs where needed so that charts show
+// income, expenses, and balances as positive numbers.
+
+// MonthlyIncomeExpense sums income and expenses by month for the months from
+// start to end, inclusive. Transactions must include their lines, and accounts
+// are classified by their Category.
+func MonthlyIncomeExpense(txns []aplos.Transaction, accts []aplos.Account, start, end aplos.Date) *TimeSeries {
+	categories := make(map[int]string)
+	for _, a := range accts {
+		categories[a.AccountNumber] = strings.ToLower(a.Category)
+	}
+
+	labels, idx := months(start, end)
+	income := make([]aplos.Amount, len(labels))
+	expense := make([]aplos.Amount, len(labels))
+	for _, t := range txns {
+		i, ok := idx[monthLabel(t.Date)]
+		if !ok {
+			continue
+		}
+		for _, l := range t.Lines {
+			switch categories[l.Account.AccountNumber] {
+			case "income":
+				income[i] -= l.Amount
+			case "expense":
+				expense[i] += l.Amount
+			}
+		}
+	}
+
+	return &TimeSeries{
+		Labels: labels,
+		Series: []Series{
+			{Name: "Income", Values: dollars(income)},
+			{Name: "Expense", Values: dollars(expense)},
+		},
+	}
+}
+
+// MonthlyBalance computes the month-end balance of the given accounts (e.g. all
+// cash accounts, for a cash balance chart) for the months from start to end,
+// inclusive. The opening balance is the combined balance of the accounts as of
+// the start of the first month, and transactions before then are ignored.
+func MonthlyBalance(name string, txns []aplos.Transaction, accountNumbers []int, opening aplos.Amount, start, end aplos.Date) *TimeSeries {
+	labels, idx := months(start, end)
+	change := monthlySums(txns, accountNumbers, idx, len(labels), 1)
+
+	balance := opening
+	values := make([]aplos.Amount, len(labels))
+	for i, c := range change {
+		balance += c
+		values[i] = balance
+	}
+
+	return &TimeSeries{
+		Labels: labels,
+		Series: []Series{{Name: name, Values: dollars(values)}},
+	}
+}
+
+// MonthlyTotals sums activity in the given accounts by month, for the months
+// from start to end, inclusive. Amounts are credit-positive, so that totals
+// for income accounts, like donation income for giving by month, come out
+// positive.
+func MonthlyTotals(name string, txns []aplos.Transaction, accountNumbers []int, start, end aplos.Date) *TimeSeries {
+	labels, idx := months(start, end)
+	values := monthlySums(txns, accountNumbers, idx, len(labels), -1)
+
+	return &TimeSeries{
+		Labels: labels,
+		Series: []Series{{Name: name, Values: dollars(values)}},
+	}
+}
+
+// monthlySums sums line amounts in the given accounts by month, multiplied by
+// sign.
+func monthlySums(txns []aplos.Transaction, accountNumbers []int, idx map[string]int, n int, sign aplos.Amount) []aplos.Amount {
+	want := make(map[int]bool)
+	for _, a := range accountNumbers {
+		want[a] = true
+	}
+
+	sums := make([]aplos.Amount, n)
+	for _, t := range txns {
+		i, ok := idx[monthLabel(t.Date)]
+		if !ok {
+			continue
+		}
+		for _, l := range t.Lines {
+			if want[l.Account.AccountNumber] {
+				sums[i] += sign * l.Amount
+			}
+		}
+	}
+	return sums
+}
+
+func dollars(amts []aplos.Amount) []float64 {
+	out := make([]float64, len(amts))
+	for i, a := range amts {
+		out[i] = a.Float64()
+	}
+	return out
+}
+
+// months returns labels for each month from start to end, inclusive, and a map
+// from label to index.
+func months(start, end aplos.Date) ([]string, map[string]int) {
+	var labels []string
+	idx := make(map[string]int)
+	cur := time.Date(start.Year, start.Month, 1, 0, 0, 0, 0, time.UTC)
+	last := time.Date(end.Year, end.Month, 1, 0, 0, 0, 0, time.UTC)
+	for !cur.After(last) {
+		l := monthLabel(aplos.Date{Year: cur.Year(), Month: cur.Month()})
+		idx[l] = len(labels)
+		labels = append(labels, l)
+		cur = cur.AddDate(0, 1, 0)
+	}
+	return labels, idx
+}
+
+func monthLabel(d aplos.Date) string {
+	return fmt.Sprintf("%04d-%02d", d.Year, d.Month)
+}
+
+// Table lays out the time series with one row per period and one column per
+// series.
+func (ts *TimeSeries) Table(f CurrencyFormat) *Table {
+	t := &Table{Columns: []Column{{Header: "Period"}}}
+	for _, s := range ts.Series {
+		t.Columns = append(t.Columns, Column{Header: s.Name, Align: AlignRight})
+	}
+	for i, label := range ts.Labels {
+		row := []string{label}
+		for _, s := range ts.Series {
+			var v string
+			if i < len(s.Values) {
+				v = f.Format(s.Values[i])
+			}
+			row = append(row, v)
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	return t
+}
@@ -0,0 +1,97 @@
+package report
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Silicon-Ally/aplos"
+)
+
+const (
+	checking  = 1000
+	donations = 4000
+	rent      = 5000
+)
+
+func line(acct int, amt aplos.Amount) aplos.TransactionLine {
+	return aplos.TransactionLine{Amount: amt, Account: aplos.Account{AccountNumber: acct}}
+}
+
+func testLedger() ([]aplos.Transaction, []aplos.Account) {
+	accts := []aplos.Account{
+		{AccountNumber: checking, Category: "asset"},
+		{AccountNumber: donations, Category: "income"},
+		{AccountNumber: rent, Category: "expense"},
+	}
+	txns := []aplos.Transaction{
+		{
+			ID:    1,
+			Date:  aplos.Date{Year: 2022, Month: time.December, Day: 31},
+			Lines: []aplos.TransactionLine{line(checking, 50_00), line(donations, -50_00)},
+		},
+		{
+			ID:    2,
+			Date:  aplos.Date{Year: 2023, Month: time.January, Day: 5},
+			Lines: []aplos.TransactionLine{line(checking, 100_00), line(donations, -100_00)},
+		},
+		{
+			ID:    3,
+			Date:  aplos.Date{Year: 2023, Month: time.January, Day: 20},
+			Lines: []aplos.TransactionLine{line(rent, 40_00), line(checking, -40_00)},
+		},
+		{
+			ID:    4,
+			Date:  aplos.Date{Year: 2023, Month: time.March, Day: 1},
+			Lines: []aplos.TransactionLine{line(checking, 25_00), line(donations, -25_00)},
+		},
+	}
+	return txns, accts
+}
+
+var (
+	jan = aplos.Date{Year: 2023, Month: time.January, Day: 1}
+	mar = aplos.Date{Year: 2023, Month: time.March, Day: 31}
+)
+
+func TestMonthlyIncomeExpense(t *testing.T) {
+	txns, accts := testLedger()
+	got := MonthlyIncomeExpense(txns, accts, jan, mar)
+	want := &TimeSeries{
+		Labels: []string{"2023-01", "2023-02", "2023-03"},
+		Series: []Series{
+			{Name: "Income", Values: []float64{100, 0, 25}},
+			{Name: "Expense", Values: []float64{40, 0, 0}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MonthlyIncomeExpense() = %+v, want %+v", got, want)
+	}
+}
+
+func TestMonthlyBalance(t *testing.T) {
+	txns, _ := testLedger()
+	got := MonthlyBalance("Cash", txns, []int{checking}, 50_00, jan, mar)
+	want := &TimeSeries{
+		Labels: []string{"2023-01", "2023-02", "2023-03"},
+		Series: []Series{{Name: "Cash", Values: []float64{110, 110, 135}}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MonthlyBalance() = %+v, want %+v", got, want)
+	}
+}
+
+func TestMonthlyTotals(t *testing.T) {
+	txns, _ := testLedger()
+	got := MonthlyTotals("Giving", txns, []int{donations}, jan, mar)
+
+	dat, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	want := `{"labels":["2023-01","2023-02","2023-03"],"series":[{"name":"Giving","values":[100,0,25]}]}`
+	if string(dat) != want {
+		t.Errorf("MonthlyTotals() = %s, want %s", dat, want)
+	}
+}
@@ -0,0 +1,85 @@
+package aplos
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// WithRetry makes the client retry requests that fail with a 429, a 5xx, or a
+// transient network error, up to max times. Retries are spaced out with
+// exponential backoff starting at baseDelay, i.e. baseDelay, 2*baseDelay,
+// 4*baseDelay, and so on, unless the response has a Retry-After header, which
+// is honored instead.
+//
+// Since a POST or PATCH that failed with a 5xx or a network error may still
+// have been applied, and retrying it could e.g. record a contribution twice,
+// only idempotent requests (GET, HEAD, PUT, DELETE, and OPTIONS) are retried on
+// those. Any request is retried on a 429.
+func WithRetry(max int, baseDelay time.Duration) Option {
+	return func(o *clientOpts) {
+		o.retry = &retryPolicy{max: max, baseDelay: baseDelay}
+	}
+}
+
+// retryPolicy decides whether and when to retry failed requests. A nil
+// *retryPolicy never retries.
+type retryPolicy struct {
+	max       int
+	baseDelay time.Duration
+}
+
+// delay returns how long to wait before retrying a request with the given
+// method that failed with err on the given (zero-based) attempt, and false if
+// it shouldn't be retried.
+func (p *retryPolicy) delay(ctx context.Context, attempt int, method string, err error) (time.Duration, bool) {
+	if p == nil || err == nil || attempt >= p.max || ctx.Err() != nil {
+		return 0, false
+	}
+
+	var apiErr *APIError
+	isAPIErr := errors.As(err, &apiErr)
+	if !idempotent(method) && !(isAPIErr && apiErr.StatusCode == http.StatusTooManyRequests) {
+		return 0, false
+	}
+	if isAPIErr && apiErr.hasRetryAfter && apiErr.Retryable() {
+		return apiErr.retryAfter, true
+	}
+	if _, ok := RetryAfter(err); !ok && !isTransientNetworkError(err) {
+		return 0, false
+	}
+	return p.baseDelay << attempt, true
+}
+
+// idempotent reports whether repeating a request with the given method has the
+// same effect as making it once.
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+// isTransientNetworkError reports whether err is a dropped connection, which
+// RetryAfter doesn't consider retryable on its own, since the request may have
+// been processed.
+func isTransientNetworkError(err error) bool {
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
+
+// sleep waits for d, or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,126 @@
+package aplos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetry(t *testing.T) {
+	tests := []struct {
+		desc         string
+		method       string
+		statuses     []int // Responses to send before succeeding.
+		max          int
+		wantRequests int
+		wantErr      bool
+	}{
+		{desc: "succeeds after 5xx", method: http.MethodGet, statuses: []int{500, 503}, max: 3, wantRequests: 3},
+		{desc: "succeeds after 429", method: http.MethodGet, statuses: []int{429}, max: 3, wantRequests: 2},
+		{desc: "gives up after max retries", method: http.MethodGet, statuses: []int{500, 500, 500}, max: 2, wantRequests: 3, wantErr: true},
+		{desc: "doesn't retry 404", method: http.MethodGet, statuses: []int{404}, max: 3, wantRequests: 1, wantErr: true},
+		{desc: "retries PUT on 5xx", method: http.MethodPut, statuses: []int{502}, max: 3, wantRequests: 2},
+		{desc: "doesn't retry POST on 5xx", method: http.MethodPost, statuses: []int{500}, max: 3, wantRequests: 1, wantErr: true},
+		{desc: "retries POST on 429", method: http.MethodPost, statuses: []int{429}, max: 3, wantRequests: 2},
+		{desc: "doesn't retry PATCH on 5xx", method: http.MethodPatch, statuses: []int{503}, max: 3, wantRequests: 1, wantErr: true},
+		{desc: "retries DELETE on 5xx", method: http.MethodDelete, statuses: []int{500}, max: 3, wantRequests: 2},
+		{desc: "no retries by default", method: http.MethodGet, statuses: []int{500}, max: 0, wantRequests: 1, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			var requests int
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if r.Method == http.MethodPost || r.Method == http.MethodPut || r.Method == http.MethodPatch {
+					if body := readAll(r); body != `{"a":1}` {
+						t.Errorf("attempt %d had body %q, want the original body", requests, body)
+					}
+				}
+				if requests <= len(test.statuses) {
+					w.WriteHeader(test.statuses[requests-1])
+					return
+				}
+				fmt.Fprint(w, `{}`)
+			}))
+			if test.max > 0 {
+				c.retry = &retryPolicy{max: test.max, baseDelay: time.Millisecond}
+			}
+
+			var body interface{}
+			if test.method != http.MethodGet {
+				body = map[string]int{"a": 1}
+			}
+			err := c.Do(context.Background(), test.method, "/things", nil, body, nil)
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Errorf("Do() = %v, want error: %t", err, test.wantErr)
+			}
+			if requests != test.wantRequests {
+				t.Errorf("server got %d requests, want %d", requests, test.wantRequests)
+			}
+		})
+	}
+}
+
+func TestRetryNetworkError(t *testing.T) {
+	var requests int
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			// Drop the connection without a response.
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Errorf("failed to hijack connection: %v", err)
+				return
+			}
+			conn.Close()
+			return
+		}
+		fmt.Fprint(w, `{"status": 200, "data": {"fund": {"id": 1}}}`)
+	}))
+	c.retry = &retryPolicy{max: 1, baseDelay: time.Millisecond}
+
+	if _, err := c.Fund(context.Background(), 1); err != nil {
+		t.Errorf("Fund: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("server got %d requests, want 2", requests)
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	p := &retryPolicy{max: 5, baseDelay: 100 * time.Millisecond}
+	ctx := context.Background()
+	serverErr := &APIError{StatusCode: http.StatusServiceUnavailable}
+
+	for attempt, want := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond} {
+		if got, ok := p.delay(ctx, attempt, http.MethodGet, serverErr); !ok || got != want {
+			t.Errorf("delay(attempt %d) = %v, %t, want %v", attempt, got, ok, want)
+		}
+	}
+
+	withHeader := &APIError{StatusCode: http.StatusTooManyRequests, retryAfter: 7 * time.Second, hasRetryAfter: true}
+	if got, ok := p.delay(ctx, 3, http.MethodGet, withHeader); !ok || got != 7*time.Second {
+		t.Errorf("delay() with Retry-After = %v, %t, want 7s", got, ok)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, ok := p.delay(canceled, 0, http.MethodGet, serverErr); ok {
+		t.Error("delay() with a canceled context = true, want false")
+	}
+	if _, ok := p.delay(ctx, 0, http.MethodGet, errors.New("failed to decode response")); ok {
+		t.Error("delay() for a decode error = true, want false")
+	}
+}
+
+func readAll(r *http.Request) string {
+	var b strings.Builder
+	io.Copy(&b, r.Body)
+	return b.String()
+}
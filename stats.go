@@ -0,0 +1,186 @@
+package aplos
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EndpointStats counts the requests made to a single API endpoint.
+type EndpointStats struct {
+	// Requests is the number of requests sent to the API, including ones that
+	// failed. Responses served from the HTTP cache aren't counted, since they
+	// don't consume any quota.
+	Requests int
+	// Errors is the number of requests that failed, either with a non-2xx
+	// status or without getting a response at all.
+	Errors int
+	// RateLimited is the number of requests rejected with a 429 Too Many
+	// Requests, a sign that the job is running up against the API's limits.
+	RateLimited int
+}
+
+func (s *EndpointStats) add(o EndpointStats) {
+	s.Requests += o.Requests
+	s.Errors += o.Errors
+	s.RateLimited += o.RateLimited
+}
+
+// Stats summarizes a client's API usage, for batch jobs to log how much of the
+// API's quota they consumed. See Client.Stats.
+type Stats struct {
+	// Since is when the client was created, or when its stats were last reset.
+	Since time.Time
+	// Endpoints maps endpoints, like "GET /transactions/{id}", to the requests
+	// made to them. IDs and other variable path segments are replaced with
+	// placeholders, so that all requests to an endpoint are counted together.
+	// Authentication requests are counted under "GET /auth/{client_id}".
+	Endpoints map[string]EndpointStats
+	// PeakReadRPS and PeakWriteRPS are the most read and write requests sent in
+	// any one second, which can be compared against the API's rate limits or
+	// the client's own RateLimits.
+	PeakReadRPS  int
+	PeakWriteRPS int
+}
+
+// Total returns the sum of the requests made to all endpoints.
+func (s Stats) Total() EndpointStats {
+	var total EndpointStats
+	for _, es := range s.Endpoints {
+		total.add(es)
+	}
+	return total
+}
+
+// Stats returns the client's cumulative API usage, per endpoint.
+func (c *Client) Stats() Stats {
+	return c.stats.snapshot()
+}
+
+// ResetStats zeroes the client's usage stats, e.g. between the runs of a
+// long-lived service.
+func (c *Client) ResetStats() {
+	c.stats.reset()
+}
+
+// stats is the mutable state behind Client.Stats. A nil *stats records
+// nothing.
+type stats struct {
+	mu        sync.Mutex
+	since     time.Time
+	endpoints map[string]EndpointStats
+
+	// second is the Unix time of the current one second window, and reads and
+	// writes are the number of requests sent in it.
+	second        int64
+	reads, writes int
+	peakReads     int
+	peakWrites    int
+
+	now func() time.Time
+}
+
+func newStats() *stats {
+	s := &stats{now: time.Now}
+	s.reset()
+	return s
+}
+
+func (s *stats) reset() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.since = s.now()
+	s.endpoints = make(map[string]EndpointStats)
+	s.second, s.reads, s.writes, s.peakReads, s.peakWrites = 0, 0, 0, 0, 0
+}
+
+func (s *stats) snapshot() Stats {
+	if s == nil {
+		return Stats{Endpoints: map[string]EndpointStats{}}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	endpoints := make(map[string]EndpointStats, len(s.endpoints))
+	for k, v := range s.endpoints {
+		endpoints[k] = v
+	}
+	return Stats{
+		Since:        s.since,
+		Endpoints:    endpoints,
+		PeakReadRPS:  s.peakReads,
+		PeakWriteRPS: s.peakWrites,
+	}
+}
+
+// record counts a request to the given endpoint, which got a response with the
+// given status code, or zero if the request failed without one.
+func (s *stats) record(method, endpoint string, status int) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	es := s.endpoints[method+" "+endpoint]
+	es.Requests++
+	if status < 200 || status >= 300 {
+		es.Errors++
+	}
+	if status == http.StatusTooManyRequests {
+		es.RateLimited++
+	}
+	s.endpoints[method+" "+endpoint] = es
+
+	if sec := s.now().Unix(); sec != s.second {
+		s.second, s.reads, s.writes = sec, 0, 0
+	}
+	if isUnsafeMethod(method) {
+		s.writes++
+		s.peakWrites = max(s.peakWrites, s.writes)
+	} else {
+		s.reads++
+		s.peakReads = max(s.peakReads, s.reads)
+	}
+}
+
+// statsTransport records requests that are actually sent to the API. It goes
+// inside the cache and rate limiter, so that cache hits and time spent waiting
+// for the rate limiter aren't counted.
+type statsTransport struct {
+	next    http.RoundTripper
+	stats   *stats
+	baseURL string
+}
+
+func (t *statsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	status := 0
+	if err == nil {
+		status = resp.StatusCode
+	}
+	t.stats.record(req.Method, endpointFor(t.baseURL, req), status)
+	return resp, err
+}
+
+// endpointFor returns the path of req relative to the API base URL, with
+// numeric path segments replaced by "{id}".
+func endpointFor(baseURL string, req *http.Request) string {
+	u := *req.URL
+	u.RawQuery, u.Fragment = "", ""
+	path := strings.TrimPrefix(u.String(), baseURL)
+
+	segs := strings.Split(path, "/")
+	for i, seg := range segs {
+		switch {
+		case i == 2 && segs[1] == "auth":
+			segs[i] = "{client_id}"
+		case seg != "" && strings.Trim(seg, "0123456789") == "":
+			segs[i] = "{id}"
+		}
+	}
+	return strings.Join(segs, "/")
+}
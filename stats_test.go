@@ -0,0 +1,84 @@
+package aplos
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStats(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/transactions/1", "/transactions/2":
+			fmt.Fprint(w, `{"status": 200, "data": {"transaction": {"id": 1}}}`)
+		case "/transactions/3":
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "/contacts":
+			fmt.Fprint(w, `{"status": 200, "data": {"contact": {"id": 5}}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	now := time.Date(2023, time.May, 1, 12, 0, 0, 0, time.UTC)
+	st := &stats{now: func() time.Time { return now }}
+	st.reset()
+	hc := srv.Client()
+	hc.Transport = &statsTransport{next: hc.Transport, stats: st, baseURL: srv.URL}
+	c := &Client{http: hc, baseURL: srv.URL, stats: st}
+
+	ctx := context.Background()
+	for _, id := range []int{1, 2, 3} {
+		c.Transaction(ctx, id)
+	}
+	now = now.Add(time.Second)
+	c.CreateContact(ctx, Contact{Type: ContactTypeIndividual})
+	c.Fund(ctx, 4)
+
+	got := c.Stats()
+	want := Stats{
+		Since: time.Date(2023, time.May, 1, 12, 0, 0, 0, time.UTC),
+		Endpoints: map[string]EndpointStats{
+			"GET /transactions/{id}": {Requests: 3, Errors: 1, RateLimited: 1},
+			"POST /contacts":         {Requests: 1},
+			"GET /funds/{id}":        {Requests: 1, Errors: 1},
+		},
+		PeakReadRPS:  3,
+		PeakWriteRPS: 1,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+	if got, want := got.Total(), (EndpointStats{Requests: 5, Errors: 2, RateLimited: 1}); got != want {
+		t.Errorf("Total() = %+v, want %+v", got, want)
+	}
+
+	c.ResetStats()
+	if got := c.Stats(); len(got.Endpoints) != 0 || got.PeakReadRPS != 0 || !got.Since.Equal(now) {
+		t.Errorf("Stats() after ResetStats = %+v, want empty stats since %v", got, now)
+	}
+}
+
+func TestEndpointFor(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://example.com/api/v1/transactions?page=2", "/transactions"},
+		{"https://example.com/api/v1/transactions/123", "/transactions/{id}"},
+		{"https://example.com/api/v1/accounts/5000", "/accounts/{id}"},
+		{"https://example.com/api/v1/reports/fundbalances", "/reports/fundbalances"},
+		{"https://example.com/api/v1/auth/abc-123", "/auth/{client_id}"},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodGet, test.url, nil)
+		if got := endpointFor("https://example.com/api/v1", req); got != test.want {
+			t.Errorf("endpointFor(%q) = %q, want %q", test.url, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package aplos_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Silicon-Ally/aplos/aplostest"
+	"golang.org/x/oauth2"
+)
+
+func TestTokenSource(t *testing.T) {
+	srv := aplostest.NewServer()
+	defer srv.Close()
+	srv.Load(aplostest.SmallNonprofit())
+	c, err := srv.Client()
+	if err != nil {
+		t.Fatalf("Client: %v", err)
+	}
+
+	hc := oauth2.NewClient(context.Background(), c.TokenSource())
+	resp, err := hc.Get(srv.URL + "/funds")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if got, want := c.Stats().Endpoints["GET /auth/{client_id}"].Requests, 1; got != want {
+		t.Errorf("auth requests = %d, want %d", got, want)
+	}
+}
@@ -3,6 +3,8 @@ package aplos
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -21,7 +23,7 @@ func (t *Time) UnmarshalJSON(data []byte) error {
 		return fmt.Errorf("failed to unmarshal JSON field as a string: %w", err)
 	}
 
-	tmp, err := time.Parse("2006-01-02T15:04:05.999-0700", s)
+	tmp, err := time.Parse(timeFormat, s)
 	if err != nil {
 		return fmt.Errorf("failed to parse time: %w", err)
 	}
@@ -30,6 +32,18 @@ func (t *Time) UnmarshalJSON(data []byte) error {
 	return err
 }
 
+// timeFormat is the format of time fields in the Aplos API.
+const timeFormat = "2006-01-02T15:04:05.999-0700"
+
+// MarshalJSON encodes t in the API's format, or as null if t is zero, so that
+// it round-trips through UnmarshalJSON.
+func (t Time) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + t.Format(timeFormat) + `"`), nil
+}
+
 type Date struct {
 	Year  int
 	Month time.Month
@@ -40,6 +54,12 @@ func (d *Date) UnmarshalJSON(data []byte) error {
 	if string(data) == "null" {
 		return nil
 	}
+	// Dates show up on every transaction, so we avoid allocating for the common
+	// case of a plain "YYYY-MM-DD" string.
+	if dt, ok := parseDateFast(data); ok {
+		*d = dt
+		return nil
+	}
 	var s string
 	if err := json.Unmarshal(data, &s); err != nil {
 		return fmt.Errorf("failed to unmarshal JSON field as a string: %w", err)
@@ -59,6 +79,104 @@ func (d *Date) UnmarshalJSON(data []byte) error {
 	return err
 }
 
+// parseDateFast parses a JSON string of the form "YYYY-MM-DD" without
+// allocating. It returns false if data isn't of that form or isn't a valid
+// date.
+func parseDateFast(data []byte) (Date, bool) {
+	if len(data) != 12 || data[0] != '"' || data[11] != '"' || data[5] != '-' || data[8] != '-' {
+		return Date{}, false
+	}
+	y, ok1 := atoi(data[1:5])
+	m, ok2 := atoi(data[6:8])
+	day, ok3 := atoi(data[9:11])
+	if !ok1 || !ok2 || !ok3 || m < 1 || m > 12 || day < 1 {
+		return Date{}, false
+	}
+	// Check the day is valid for the month, e.g. reject 2023-02-30.
+	if _, _, nd := time.Date(y, time.Month(m), day, 0, 0, 0, 0, time.UTC).Date(); nd != day {
+		return Date{}, false
+	}
+	return Date{Year: y, Month: time.Month(m), Day: day}, true
+}
+
+func atoi(b []byte) (int, bool) {
+	n := 0
+	for _, c := range b {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		n = n*10 + int(c-'0')
+	}
+	return n, true
+}
+
 func (d Date) String() string {
 	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
 }
+
+// Time returns midnight UTC at the start of the date.
+func (d Date) Time() time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC)
+}
+
+// Before reports whether d is before o.
+func (d Date) Before(o Date) bool {
+	if d.Year != o.Year {
+		return d.Year < o.Year
+	}
+	if d.Month != o.Month {
+		return d.Month < o.Month
+	}
+	return d.Day < o.Day
+}
+
+// After reports whether d is after o.
+func (d Date) After(o Date) bool {
+	return o.Before(d)
+}
+
+// AddDays returns the date n days after d, or before it if n is negative.
+func (d Date) AddDays(n int) Date {
+	y, m, day := d.Time().AddDate(0, 0, n).Date()
+	return Date{Year: y, Month: m, Day: day}
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+// RefNumber is a register reference number, like a check number, stored in the
+// id_number field of a transaction. Zero means the transaction has no
+// reference number. The API isn't consistent about whether these are returned
+// as JSON numbers or strings, so both are accepted.
+type RefNumber int
+
+func (r *RefNumber) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var n int
+	if err := json.Unmarshal(data, &n); err == nil {
+		*r = RefNumber(n)
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("failed to unmarshal reference number as a number or string: %w", err)
+	}
+	s = strings.TrimSpace(s)
+	if s == "" {
+		*r = 0
+		return nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return fmt.Errorf("failed to parse reference number %q: %w", s, err)
+	}
+	*r = RefNumber(n)
+	return nil
+}
+
+func (r RefNumber) String() string {
+	return strconv.Itoa(int(r))
+}